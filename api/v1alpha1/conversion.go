@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+)
+
+// labelSelectorAnnotation is set on a converted v1alpha2 InferencePool whose v1alpha1 selector was
+// set with LabelSelector although it can be expressed with Selector, so that converting back
+// yields the same v1alpha1 InferencePool.
+const labelSelectorAnnotation = "inference.networking.x-k8s.io/v1alpha1-label-selector"
+
+var (
+	_ conversion.Convertible = &InferenceModel{}
+	_ conversion.Convertible = &InferencePool{}
+	_ conversion.Convertible = &InferencePoolGrant{}
+)
+
+// ConvertTo converts the InferenceModel to the v1alpha2 hub version.
+func (src *InferenceModel) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha2.InferenceModel)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferenceModel, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = v1alpha2.InferenceModelSpec{
+		ModelName:   src.Spec.ModelName,
+		Criticality: (*v1alpha2.Criticality)(src.Spec.Criticality),
+		PoolRef:     v1alpha2.PoolObjectReference(src.Spec.PoolRef),
+	}
+	for _, target := range src.Spec.TargetModels {
+		dst.Spec.TargetModels = append(dst.Spec.TargetModels, v1alpha2.TargetModel{
+			Name:   target.Name,
+			Weight: copyPointer(target.Weight),
+		})
+	}
+	dst.Status = v1alpha2.InferenceModelStatus{Conditions: copyConditions(src.Status.Conditions)}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha2 hub version to an InferenceModel.
+func (dst *InferenceModel) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha2.InferenceModel)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferenceModel, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = InferenceModelSpec{
+		ModelName:   src.Spec.ModelName,
+		Criticality: (*Criticality)(src.Spec.Criticality),
+		PoolRef:     PoolObjectReference(src.Spec.PoolRef),
+	}
+	for _, target := range src.Spec.TargetModels {
+		dst.Spec.TargetModels = append(dst.Spec.TargetModels, TargetModel{
+			Name:   target.Name,
+			Weight: copyPointer(target.Weight),
+		})
+	}
+	dst.Status = InferenceModelStatus{Conditions: copyConditions(src.Status.Conditions)}
+	return nil
+}
+
+// ConvertTo converts the InferencePool to the v1alpha2 hub version. Selector is converted to the
+// MatchLabels of the v1alpha2 selector, and ExtensionRef to an Extension endpoint picker.
+func (src *InferencePool) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha2.InferencePool)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferencePool, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = v1alpha2.InferencePoolSpec{TargetPortNumber: src.Spec.TargetPortNumber}
+	switch {
+	case src.Spec.LabelSelector != nil:
+		src.Spec.LabelSelector.DeepCopyInto(&dst.Spec.Selector)
+		if len(src.Spec.LabelSelector.MatchExpressions) == 0 {
+			metav1.SetMetaDataAnnotation(&dst.ObjectMeta, labelSelectorAnnotation, "true")
+		}
+	case src.Spec.Selector != nil:
+		dst.Spec.Selector.MatchLabels = make(map[string]string, len(src.Spec.Selector))
+		for k, v := range src.Spec.Selector {
+			dst.Spec.Selector.MatchLabels[string(k)] = string(v)
+		}
+	}
+	if src.Spec.ExtensionRef != nil {
+		dst.Spec.EndpointPicker = v1alpha2.EndpointPickerConfig{
+			Type: v1alpha2.EndpointPickerTypeExtension,
+			Extension: &v1alpha2.Extension{
+				ExtensionReference: v1alpha2.ExtensionReference{
+					Group:            copyPointer(src.Spec.ExtensionRef.Group),
+					Kind:             copyPointer(src.Spec.ExtensionRef.Kind),
+					Name:             src.Spec.ExtensionRef.Name,
+					TargetPortNumber: copyPointer(src.Spec.ExtensionRef.TargetPortNumber),
+				},
+				ExtensionConnection: v1alpha2.ExtensionConnection{
+					FailureMode: (*v1alpha2.ExtensionFailureMode)(copyPointer((*string)(src.Spec.ExtensionRef.FailureMode))),
+				},
+			},
+		}
+	}
+	dst.Status = v1alpha2.InferencePoolStatus{Conditions: copyConditions(src.Status.Conditions)}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha2 hub version to an InferencePool. A v1alpha2 selector using
+// only MatchLabels is converted to Selector, other selectors to LabelSelector.
+func (dst *InferencePool) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha2.InferencePool)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferencePool, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	_, useLabelSelector := dst.Annotations[labelSelectorAnnotation]
+	delete(dst.Annotations, labelSelectorAnnotation)
+	dst.Spec = InferencePoolSpec{TargetPortNumber: src.Spec.TargetPortNumber}
+	switch {
+	case useLabelSelector || len(src.Spec.Selector.MatchExpressions) > 0:
+		dst.Spec.LabelSelector = src.Spec.Selector.DeepCopy()
+	case src.Spec.Selector.MatchLabels != nil:
+		dst.Spec.Selector = make(map[LabelKey]LabelValue, len(src.Spec.Selector.MatchLabels))
+		for k, v := range src.Spec.Selector.MatchLabels {
+			dst.Spec.Selector[LabelKey(k)] = LabelValue(v)
+		}
+	}
+	if ext := src.Spec.EndpointPicker.Extension; ext != nil {
+		dst.Spec.ExtensionRef = &Extension{
+			ExtensionReference: ExtensionReference{
+				Group:            copyPointer(ext.Group),
+				Kind:             copyPointer(ext.Kind),
+				Name:             ext.Name,
+				TargetPortNumber: copyPointer(ext.TargetPortNumber),
+			},
+			ExtensionConnection: ExtensionConnection{
+				FailureMode: (*ExtensionFailureMode)(copyPointer((*string)(ext.FailureMode))),
+			},
+		}
+	}
+	dst.Status = InferencePoolStatus{Conditions: copyConditions(src.Status.Conditions)}
+	return nil
+}
+
+// ConvertTo converts the InferencePoolGrant to the v1alpha2 hub version.
+func (src *InferencePoolGrant) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha2.InferencePoolGrant)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferencePoolGrant, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = v1alpha2.InferencePoolGrantSpec{}
+	for _, from := range src.Spec.From {
+		dst.Spec.From = append(dst.Spec.From, v1alpha2.InferencePoolGrantFrom(from))
+	}
+	for _, to := range src.Spec.To {
+		dst.Spec.To = append(dst.Spec.To, v1alpha2.InferencePoolGrantTo{Name: copyPointer(to.Name)})
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha2 hub version to an InferencePoolGrant.
+func (dst *InferencePoolGrant) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha2.InferencePoolGrant)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha2 InferencePoolGrant, got %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = InferencePoolGrantSpec{}
+	for _, from := range src.Spec.From {
+		dst.Spec.From = append(dst.Spec.From, InferencePoolGrantFrom(from))
+	}
+	for _, to := range src.Spec.To {
+		dst.Spec.To = append(dst.Spec.To, InferencePoolGrantTo{Name: copyPointer(to.Name)})
+	}
+	return nil
+}
+
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(conditions))
+	for i := range conditions {
+		conditions[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
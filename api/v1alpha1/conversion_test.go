@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+)
+
+const fuzzIterations = 1000
+
+func TestFuzzyConversion(t *testing.T) {
+	tests := []struct {
+		name  string
+		spoke func() conversion.Convertible
+		hub   func() conversion.Hub
+	}{
+		{
+			name:  "InferenceModel",
+			spoke: func() conversion.Convertible { return &InferenceModel{} },
+			hub:   func() conversion.Hub { return &v1alpha2.InferenceModel{} },
+		},
+		{
+			name:  "InferencePool",
+			spoke: func() conversion.Convertible { return &InferencePool{} },
+			hub:   func() conversion.Hub { return &v1alpha2.InferencePool{} },
+		},
+		{
+			name:  "InferencePoolGrant",
+			spoke: func() conversion.Convertible { return &InferencePoolGrant{} },
+			hub:   func() conversion.Hub { return &v1alpha2.InferencePoolGrant{} },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name+" spoke-hub-spoke", func(t *testing.T) {
+			f := newFuzzer(t)
+			for range fuzzIterations {
+				spoke := test.spoke()
+				f.Fuzz(spoke)
+				hub := test.hub()
+				if err := spoke.DeepCopyObject().(conversion.Convertible).ConvertTo(hub); err != nil {
+					t.Fatalf("Failed to convert to hub: %v", err)
+				}
+				got := test.spoke()
+				if err := got.ConvertFrom(hub); err != nil {
+					t.Fatalf("Failed to convert from hub: %v", err)
+				}
+				if !apiequality.Semantic.DeepEqual(spoke, got) {
+					t.Fatalf("Round trip changed the object (-want +got): %s", cmp.Diff(spoke, got))
+				}
+			}
+		})
+		t.Run(test.name+" hub-spoke-hub", func(t *testing.T) {
+			f := newFuzzer(t)
+			for range fuzzIterations {
+				hub := test.hub()
+				f.Fuzz(hub)
+				spoke := test.spoke()
+				if err := spoke.ConvertFrom(hub.DeepCopyObject().(conversion.Hub)); err != nil {
+					t.Fatalf("Failed to convert from hub: %v", err)
+				}
+				got := test.hub()
+				if err := spoke.ConvertTo(got); err != nil {
+					t.Fatalf("Failed to convert to hub: %v", err)
+				}
+				if !apiequality.Semantic.DeepEqual(hub, got) {
+					t.Fatalf("Round trip changed the object (-want +got): %s", cmp.Diff(hub, got))
+				}
+			}
+		})
+	}
+}
+
+func TestConvertInferencePool(t *testing.T) {
+	spoke := &InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: InferencePoolSpec{
+			Selector:         map[LabelKey]LabelValue{"app": "vllm"},
+			TargetPortNumber: 8000,
+			EndpointPickerConfig: EndpointPickerConfig{
+				ExtensionRef: &Extension{ExtensionReference: ExtensionReference{Name: "epp"}},
+			},
+		},
+	}
+	want := &v1alpha2.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: v1alpha2.InferencePoolSpec{
+			Selector:         metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}},
+			TargetPortNumber: 8000,
+			EndpointPicker: v1alpha2.EndpointPickerConfig{
+				Type:      v1alpha2.EndpointPickerTypeExtension,
+				Extension: &v1alpha2.Extension{ExtensionReference: v1alpha2.ExtensionReference{Name: "epp"}},
+			},
+		},
+	}
+	got := &v1alpha2.InferencePool{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("Failed to convert to hub: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected hub (-want +got): %s", diff)
+	}
+
+	// A LabelSelector only using MatchLabels is preserved with an annotation.
+	spoke.Spec.Selector = nil
+	spoke.Spec.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}}
+	got = &v1alpha2.InferencePool{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("Failed to convert to hub: %v", err)
+	}
+	if got.Annotations[labelSelectorAnnotation] != "true" {
+		t.Errorf("Expected the %s annotation, got %v", labelSelectorAnnotation, got.Annotations)
+	}
+	back := &InferencePool{}
+	if err := back.ConvertFrom(got); err != nil {
+		t.Fatalf("Failed to convert from hub: %v", err)
+	}
+	if !apiequality.Semantic.DeepEqual(spoke, back) {
+		t.Errorf("Unexpected round trip (-want +got): %s", cmp.Diff(spoke, back))
+	}
+}
+
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	seed := rand.Int63()
+	t.Logf("Fuzzing with seed %d", seed)
+	scheme := runtime.NewScheme()
+	return fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, fuzzerFuncs),
+		rand.NewSource(seed),
+		runtimeserializer.NewCodecFactory(scheme),
+	)
+}
+
+// fuzzerFuncs only generates objects that pass validation where the versions differ, invalid
+// objects are not guaranteed to round trip.
+func fuzzerFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		// Selector and LabelSelector are mutually exclusive.
+		func(spec *InferencePoolSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(spec)
+			if c.RandBool() {
+				spec.Selector = nil
+			} else {
+				spec.LabelSelector = nil
+			}
+		},
+		// The type of the union matches its member.
+		func(config *v1alpha2.EndpointPickerConfig, c fuzz.Continue) {
+			c.FuzzNoCustom(config)
+			config.Type = ""
+			if config.Extension != nil {
+				config.Type = v1alpha2.EndpointPickerTypeExtension
+			}
+		},
+	}
+}
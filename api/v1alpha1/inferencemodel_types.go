@@ -24,6 +24,7 @@ import (
 //
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +genclient
 type InferenceModel struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -92,13 +93,14 @@ type InferenceModelSpec struct {
 	// +kubebuilder:validation:XValidation:message="Weights should be set for all models, or none of the models.",rule="self.all(model, has(model.weight)) || self.all(model, !has(model.weight))"
 	TargetModels []TargetModel `json:"targetModels,omitempty"`
 
-	// PoolRef is a reference to the inference pool, the pool must exist in the same namespace.
+	// PoolRef is a reference to the inference pool. The pool must exist in the same namespace,
+	// unless the namespace of the reference is set and an InferencePoolGrant allows the reference.
 	//
 	// +kubebuilder:validation:Required
 	PoolRef PoolObjectReference `json:"poolRef"`
 }
 
-// PoolObjectReference identifies an API object within the namespace of the
+// PoolObjectReference identifies an InferencePool, by default within the namespace of the
 // referrer.
 type PoolObjectReference struct {
 	// Group is the group of the referent.
@@ -118,6 +120,15 @@ type PoolObjectReference struct {
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z]([-a-zA-Z0-9]*[a-zA-Z0-9])?$`
 	Kind string `json:"kind,omitempty"`
 
+	// Namespace is the namespace of the referent. When unspecified, the InferencePool is in the
+	// namespace of the InferenceModel. Referencing an InferencePool in another namespace is only
+	// allowed if an InferencePoolGrant in the namespace of the pool allows it.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^$|^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace,omitempty"`
+
 	// Name is the name of the referent.
 	//
 	// +kubebuilder:validation:MinLength=1
@@ -186,6 +197,7 @@ type InferenceModelStatus struct {
 	// Known condition types are:
 	//
 	// * "Accepted"
+	// * "Ready"
 	//
 	// +optional
 	// +listType=map
@@ -211,6 +223,7 @@ const (
 	// Possible reasons for this condition to be False are:
 	//
 	// * "ModelNameInUse"
+	// * "RefNotPermitted"
 	//
 	// Possible reasons for this condition to be Unknown are:
 	//
@@ -218,13 +231,38 @@ const (
 	//
 	ModelConditionAccepted InferenceModelConditionType = "Accepted"
 
+	// ModelConditionReady indicates if the model can be served by the pool, and if not, why.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Ready"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "ModelNameInUse"
+	// * "RefNotPermitted"
+	//
+	// Possible reasons for this condition to be Unknown are:
+	//
+	// * "Pending"
+	//
+	ModelConditionReady InferenceModelConditionType = "Ready"
+
 	// ModelReasonAccepted is the desired state. Model conforms to the state of the pool.
 	ModelReasonAccepted InferenceModelConditionReason = "Accepted"
 
+	// ModelReasonReady is the desired state of the Ready condition. Requests for the model are
+	// served by the pool.
+	ModelReasonReady InferenceModelConditionReason = "Ready"
+
 	// ModelReasonNameInUse is used when a given ModelName already exists within the pool.
 	// Details about naming conflict resolution are on the ModelName field itself.
 	ModelReasonNameInUse InferenceModelConditionReason = "ModelNameInUse"
 
+	// ModelReasonRefNotPermitted is used when the InferenceModel references an InferencePool in
+	// another namespace, and no InferencePoolGrant in that namespace allows it.
+	ModelReasonRefNotPermitted InferenceModelConditionReason = "RefNotPermitted"
+
 	// ModelReasonPending is the initial state, and indicates that the controller has not yet reconciled the InferenceModel.
 	ModelReasonPending InferenceModelConditionReason = "Pending"
 )
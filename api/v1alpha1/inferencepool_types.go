@@ -24,6 +24,10 @@ import (
 //
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 type InferencePool struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -43,14 +47,30 @@ type InferencePoolList struct {
 }
 
 // InferencePoolSpec defines the desired state of InferencePool
+//
+// +kubebuilder:validation:XValidation:rule="has(self.selector) != has(self.labelSelector)",message="exactly one of selector or labelSelector must be set"
 type InferencePoolSpec struct {
 	// Selector defines a map of labels to watch model server pods
 	// that should be included in the InferencePool.
 	// In some cases, implementations may translate this field to a Service selector, so this matches the simple
 	// map used for Service selectors instead of the full Kubernetes LabelSelector type.
 	//
-	// +kubebuilder:validation:Required
-	Selector map[LabelKey]LabelValue `json:"selector"`
+	// Exactly one of Selector or LabelSelector must be set.
+	//
+	// +optional
+	Selector map[LabelKey]LabelValue `json:"selector,omitempty"`
+
+	// LabelSelector selects the model server pods that should be included in the InferencePool
+	// using the full Kubernetes LabelSelector type, e.g. to exclude canary pods with a NotIn
+	// expression. Implementations that translate the selector to a Service selector may not
+	// support it.
+	//
+	// Exactly one of Selector or LabelSelector must be set. An empty LabelSelector, which would
+	// select every pod in the namespace, is rejected.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="(has(self.matchLabels) && size(self.matchLabels) > 0) || (has(self.matchExpressions) && size(self.matchExpressions) > 0)",message="labelSelector must set matchLabels or matchExpressions"
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
 	// TargetPortNumber defines the port number to access the selected model servers.
 	// The number must be in the range 1 to 65535.
@@ -226,7 +246,8 @@ const (
 	// PoolReasonReady is the desired state. The pool and its components are initialized and ready for traffic.
 	PoolReasonReady InferencePoolConditionReason = "Ready"
 
-	// PoolReasonEPPNotHealthy is used when the EPP has not yet passed health checks, or has started failing them.
+	// PoolReasonEPPNotHealthy is used when the EPP has not yet passed health checks, or has started failing them,
+	// e.g. because too few pods are ready or have fresh metrics.
 	PoolReasonEPPNotHealthy InferencePoolConditionReason = "EndpointPickerNotHealthy"
 
 	// PoolReasonPending is the initial state, and indicates that the controller has not yet reconciled this pool.
@@ -0,0 +1,29 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// v1alpha2 is the conversion hub, the other API versions implement conversion.Convertible and
+// convert to and from v1alpha2.
+
+// Hub marks InferenceModel as a conversion hub.
+func (*InferenceModel) Hub() {}
+
+// Hub marks InferencePool as a conversion hub.
+func (*InferencePool) Hub() {}
+
+// Hub marks InferencePoolGrant as a conversion hub.
+func (*InferencePoolGrant) Hub() {}
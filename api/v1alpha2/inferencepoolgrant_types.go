@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InferencePoolGrant allows InferenceModels in other namespaces to reference the InferencePools
+// in the namespace of the grant. It is modeled after the ReferenceGrant of the Gateway API: the
+// grant is created by the owner of the pools, in their namespace, and lists the trusted namespaces.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +genclient
+type InferencePoolGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InferencePoolGrantSpec `json:"spec,omitempty"`
+}
+
+// InferencePoolGrantList contains a list of InferencePoolGrant.
+//
+// +kubebuilder:object:root=true
+type InferencePoolGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InferencePoolGrant `json:"items"`
+}
+
+// InferencePoolGrantSpec identifies the namespaces trusted to reference the InferencePools, and
+// the InferencePools they may reference.
+type InferencePoolGrantSpec struct {
+	// From describes the trusted namespaces. InferenceModels in these namespaces may reference
+	// the InferencePools described by To.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:Required
+	From []InferencePoolGrantFrom `json:"from"`
+
+	// To describes the InferencePools that may be referenced by the InferenceModels described
+	// by From.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:Required
+	To []InferencePoolGrantTo `json:"to"`
+}
+
+// InferencePoolGrantFrom describes a trusted namespace.
+type InferencePoolGrantFrom struct {
+	// Namespace is the namespace of the referencing InferenceModels.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+}
+
+// InferencePoolGrantTo describes InferencePools that may be referenced.
+type InferencePoolGrantTo struct {
+	// Name is the name of the InferencePool. When unspecified, all InferencePools in the
+	// namespace of the grant may be referenced.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name *string `json:"name,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InferencePoolGrant{}, &InferencePoolGrantList{})
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	apiv1alpha1 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
 )
 
@@ -25,6 +26,7 @@ import (
 // with apply.
 type InferencePoolSpecApplyConfiguration struct {
 	Selector                               map[apiv1alpha1.LabelKey]apiv1alpha1.LabelValue `json:"selector,omitempty"`
+	LabelSelector                          *v1.LabelSelectorApplyConfiguration             `json:"labelSelector,omitempty"`
 	TargetPortNumber                       *int32                                          `json:"targetPortNumber,omitempty"`
 	EndpointPickerConfigApplyConfiguration `json:",inline"`
 }
@@ -49,6 +51,14 @@ func (b *InferencePoolSpecApplyConfiguration) WithSelector(entries map[apiv1alph
 	return b
 }
 
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *InferencePoolSpecApplyConfiguration) WithLabelSelector(value *v1.LabelSelectorApplyConfiguration) *InferencePoolSpecApplyConfiguration {
+	b.LabelSelector = value
+	return b
+}
+
 // WithTargetPortNumber sets the TargetPortNumber field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the TargetPortNumber field is set to the value of the last call.
@@ -20,9 +20,10 @@ package v1alpha1
 // PoolObjectReferenceApplyConfiguration represents a declarative configuration of the PoolObjectReference type for use
 // with apply.
 type PoolObjectReferenceApplyConfiguration struct {
-	Group *string `json:"group,omitempty"`
-	Kind  *string `json:"kind,omitempty"`
-	Name  *string `json:"name,omitempty"`
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Namespace *string `json:"namespace,omitempty"`
+	Name      *string `json:"name,omitempty"`
 }
 
 // PoolObjectReferenceApplyConfiguration constructs a declarative configuration of the PoolObjectReference type for use with
@@ -47,6 +48,14 @@ func (b *PoolObjectReferenceApplyConfiguration) WithKind(value string) *PoolObje
 	return b
 }
 
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PoolObjectReferenceApplyConfiguration) WithNamespace(value string) *PoolObjectReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
 // WithName sets the Name field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Name field is set to the value of the last call.
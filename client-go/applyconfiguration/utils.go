@@ -22,7 +22,9 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	testing "k8s.io/client-go/testing"
 	v1alpha1 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	v1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	apiv1alpha1 "sigs.k8s.io/gateway-api-inference-extension/client-go/applyconfiguration/api/v1alpha1"
+	apiv1alpha2 "sigs.k8s.io/gateway-api-inference-extension/client-go/applyconfiguration/api/v1alpha2"
 	internal "sigs.k8s.io/gateway-api-inference-extension/client-go/applyconfiguration/internal"
 )
 
@@ -47,6 +49,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.InferenceModelStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("InferencePool"):
 		return &apiv1alpha1.InferencePoolApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolGrant"):
+		return &apiv1alpha1.InferencePoolGrantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolGrantFrom"):
+		return &apiv1alpha1.InferencePoolGrantFromApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolGrantSpec"):
+		return &apiv1alpha1.InferencePoolGrantSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolGrantTo"):
+		return &apiv1alpha1.InferencePoolGrantToApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolSpec"):
 		return &apiv1alpha1.InferencePoolSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("InferencePoolStatus"):
@@ -56,6 +66,40 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	case v1alpha1.SchemeGroupVersion.WithKind("TargetModel"):
 		return &apiv1alpha1.TargetModelApplyConfiguration{}
 
+		// Group=inference.networking.x-k8s.io, Version=v1alpha2
+	case v1alpha2.SchemeGroupVersion.WithKind("EndpointPickerConfig"):
+		return &apiv1alpha2.EndpointPickerConfigApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("Extension"):
+		return &apiv1alpha2.ExtensionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ExtensionConnection"):
+		return &apiv1alpha2.ExtensionConnectionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ExtensionReference"):
+		return &apiv1alpha2.ExtensionReferenceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferenceModel"):
+		return &apiv1alpha2.InferenceModelApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferenceModelSpec"):
+		return &apiv1alpha2.InferenceModelSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferenceModelStatus"):
+		return &apiv1alpha2.InferenceModelStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePool"):
+		return &apiv1alpha2.InferencePoolApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolGrant"):
+		return &apiv1alpha2.InferencePoolGrantApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolGrantFrom"):
+		return &apiv1alpha2.InferencePoolGrantFromApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolGrantSpec"):
+		return &apiv1alpha2.InferencePoolGrantSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolGrantTo"):
+		return &apiv1alpha2.InferencePoolGrantToApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolSpec"):
+		return &apiv1alpha2.InferencePoolSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolStatus"):
+		return &apiv1alpha2.InferencePoolStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PoolObjectReference"):
+		return &apiv1alpha2.PoolObjectReferenceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("TargetModel"):
+		return &apiv1alpha2.TargetModelApplyConfiguration{}
+
 	}
 	return nil
 }
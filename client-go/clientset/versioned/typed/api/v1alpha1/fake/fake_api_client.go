@@ -35,6 +35,10 @@ func (c *FakeInferenceV1alpha1) InferencePools(namespace string) v1alpha1.Infere
 	return newFakeInferencePools(c, namespace)
 }
 
+func (c *FakeInferenceV1alpha1) InferencePoolGrants(namespace string) v1alpha1.InferencePoolGrantInterface {
+	return newFakeInferencePoolGrants(c, namespace)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeInferenceV1alpha1) RESTClient() rest.Interface {
@@ -20,3 +20,5 @@ package v1alpha1
 type InferenceModelExpansion interface{}
 
 type InferencePoolExpansion interface{}
+
+type InferencePoolGrantExpansion interface{}
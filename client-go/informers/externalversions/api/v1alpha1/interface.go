@@ -27,6 +27,8 @@ type Interface interface {
 	InferenceModels() InferenceModelInformer
 	// InferencePools returns a InferencePoolInformer.
 	InferencePools() InferencePoolInformer
+	// InferencePoolGrants returns a InferencePoolGrantInformer.
+	InferencePoolGrants() InferencePoolGrantInformer
 }
 
 type version struct {
@@ -49,3 +51,8 @@ func (v *version) InferenceModels() InferenceModelInformer {
 func (v *version) InferencePools() InferencePoolInformer {
 	return &inferencePoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// InferencePoolGrants returns a InferencePoolGrantInformer.
+func (v *version) InferencePoolGrants() InferencePoolGrantInformer {
+	return &inferencePoolGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
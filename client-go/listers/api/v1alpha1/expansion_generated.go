@@ -32,3 +32,11 @@ type InferencePoolListerExpansion interface{}
 // InferencePoolNamespaceListerExpansion allows custom methods to be added to
 // InferencePoolNamespaceLister.
 type InferencePoolNamespaceListerExpansion interface{}
+
+// InferencePoolGrantListerExpansion allows custom methods to be added to
+// InferencePoolGrantLister.
+type InferencePoolGrantListerExpansion interface{}
+
+// InferencePoolGrantNamespaceListerExpansion allows custom methods to be added to
+// InferencePoolGrantNamespaceLister.
+type InferencePoolGrantNamespaceListerExpansion interface{}
@@ -18,29 +18,155 @@ package main
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"github.com/go-logr/logr"
 	"google.golang.org/grpc/codes"
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
+// Services of the health server.
+const (
+	// livenessService is serving as long as the process is up.
+	livenessService = "liveness"
+	// readinessService is serving once all pools have synced and at least one pool has a
+	// schedulable pod, and until the shutdown starts. It fails closed: it is not serving as long as
+	// any pool has not synced. A single pool without a schedulable pod does not make the EPP unready
+	// if another pool has one, as that would remove it from its Service for all pools. The requests
+	// to such a pool are rejected with 429 or 503 instead. The schedulable pods of each pool are
+	// reported by its poolReadinessServicePrefix service.
+	readinessService = "readiness"
+	// poolReadinessServicePrefix is followed by the "namespace/name" of a pool to form the service
+	// reporting the readiness of the pool. It is serving once the pool has synced and has at least
+	// one schedulable pod, that is a pod that is not draining and whose metrics are fresh, and until
+	// the shutdown starts.
+	poolReadinessServicePrefix = readinessService + "/"
+	// legacyService is the service checked by the probes of earlier deployments, it reports the
+	// status of the ext_proc service. The overall health of the server ("") is the same.
+	legacyService = "inference-extension"
+
+	defaultWatchInterval = time.Second
+)
+
+// extProcService is the ext_proc service, serving like the readinessService.
+var extProcService = extProcPb.ExternalProcessor_ServiceDesc.ServiceName
+
 type healthServer struct {
-	logger    logr.Logger
-	datastore datastore.Datastore
+	logger logr.Logger
+	pools  datastore.Pools
+	// metricsStalenessThreshold returns the age after which the metrics of a pod of the given pool
+	// are not fresh anymore. It is called on every check, to follow the config of the scheduler.
+	metricsStalenessThreshold func(pool types.NamespacedName) time.Duration
+	// watchInterval is the interval at which the status of a watched service is re-evaluated.
+	watchInterval time.Duration
+	// shuttingDown returns true once the shutdown started, the ext_proc service and the readiness
+	// are not serving anymore then.
+	shuttingDown func() bool
 }
 
 func (s *healthServer) Check(ctx context.Context, in *healthPb.HealthCheckRequest) (*healthPb.HealthCheckResponse, error) {
-	if !s.datastore.PoolHasSynced() {
+	st, ok := s.status(in.Service, time.Now())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", in.Service)
+	}
+	if st != healthPb.HealthCheckResponse_SERVING {
 		s.logger.V(logutil.VERBOSE).Info("gRPC health check not serving", "service", in.Service)
-		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_NOT_SERVING}, nil
+	} else {
+		s.logger.V(logutil.VERBOSE).Info("gRPC health check serving", "service", in.Service)
 	}
-	s.logger.V(logutil.VERBOSE).Info("gRPC health check serving", "service", in.Service)
-	return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVING}, nil
+	return &healthPb.HealthCheckResponse{Status: st}, nil
 }
 
+// Watch sends the status of the service, and then every change of the status until the watch is
+// cancelled. Unknown services are reported as SERVICE_UNKNOWN, as required by the health checking
+// protocol.
 func (s *healthServer) Watch(in *healthPb.HealthCheckRequest, srv healthPb.Health_WatchServer) error {
-	return status.Error(codes.Unimplemented, "Watch is not implemented")
+	interval := s.watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := healthPb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := s.status(in.Service, time.Now())
+		if !ok {
+			st = healthPb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if st != last {
+			s.logger.V(logutil.VERBOSE).Info("gRPC health status changed", "service", in.Service, "status", st)
+			if err := srv.Send(&healthPb.HealthCheckResponse{Status: st}); err != nil {
+				return status.Errorf(codes.Canceled, "failed to send health status: %v", err)
+			}
+			last = st
+		}
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "watch cancelled")
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the status of the given service as of now, and false if the service is unknown.
+func (s *healthServer) status(service string, now time.Time) (healthPb.HealthCheckResponse_ServingStatus, bool) {
+	var serving bool
+	switch service {
+	case livenessService:
+		serving = true
+	case readinessService, "", legacyService, extProcService:
+		serving = !s.isShuttingDown() && s.pools.HasSynced() && s.anyPoolReady(now)
+	default:
+		ref, ok := strings.CutPrefix(service, poolReadinessServicePrefix)
+		if !ok {
+			return healthPb.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+		name, err := datastore.ParsePoolName(ref, "")
+		if err != nil {
+			return healthPb.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+		ds, ok := s.pools[name]
+		if !ok {
+			return healthPb.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+		serving = !s.isShuttingDown() && s.poolReady(name, ds, now)
+	}
+	if serving {
+		return healthPb.HealthCheckResponse_SERVING, true
+	}
+	return healthPb.HealthCheckResponse_NOT_SERVING, true
+}
+
+func (s *healthServer) isShuttingDown() bool {
+	return s.shuttingDown != nil && s.shuttingDown()
+}
+
+// anyPoolReady returns true if at least one pool is ready, see poolReady.
+func (s *healthServer) anyPoolReady(now time.Time) bool {
+	for name, ds := range s.pools {
+		if s.poolReady(name, ds, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// poolReady returns true if the pool has synced and has a schedulable pod, that is a pod that is
+// not draining and whose metrics are fresh.
+func (s *healthServer) poolReady(name types.NamespacedName, ds datastore.Datastore, now time.Time) bool {
+	if !ds.PoolHasSynced() {
+		return false
+	}
+	if _, fresh := datastore.CountReadyPods(ds.PodGetAll(), s.metricsStalenessThreshold(name), now); fresh == 0 {
+		s.logger.V(logutil.DEBUG).Info("No schedulable pod in pool", "pool", name)
+		return false
+	}
+	return true
 }
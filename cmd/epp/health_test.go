@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+var (
+	testPool = &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	}
+	testPod = types.NamespacedName{Namespace: "default", Name: "pod"}
+	// testPoolReadinessService reports the readiness of testPool.
+	testPoolReadinessService = poolReadinessServicePrefix + "default/pool"
+)
+
+func testStalenessThreshold(types.NamespacedName) time.Duration {
+	return 10 * time.Second
+}
+
+func TestHealthCheck(t *testing.T) {
+	now := time.Now()
+	serving := healthPb.HealthCheckResponse_SERVING
+	notServing := healthPb.HealthCheckResponse_NOT_SERVING
+
+	tests := []struct {
+		name string
+		// setup populates the datastore of the pool.
+		setup        func(ds datastore.Datastore)
+		shuttingDown bool
+		want         map[string]healthPb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:  "pool not synced",
+			setup: func(ds datastore.Datastore) {},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+				legacyService:            notServing,
+				"":                       notServing,
+			},
+		},
+		{
+			name: "no pods",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+			},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+				legacyService:            notServing,
+				"":                       notServing,
+			},
+		},
+		{
+			name: "pod never scraped",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+				ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+			},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+			},
+		},
+		{
+			name: "stale metrics",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+				ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+				ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: now.Add(-time.Minute)})
+			},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+			},
+		},
+		{
+			name: "draining pod",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+				ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", true)
+				ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: now})
+			},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+			},
+		},
+		{
+			name: "fresh metrics",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+				ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+				ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: now})
+			},
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         serving,
+				testPoolReadinessService: serving,
+				extProcService:           serving,
+			},
+		},
+		{
+			name: "shutting down",
+			setup: func(ds datastore.Datastore) {
+				ds.PoolSet(testPool)
+				ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+				ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: now})
+			},
+			shuttingDown: true,
+			want: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				livenessService:          serving,
+				readinessService:         notServing,
+				testPoolReadinessService: notServing,
+				extProcService:           notServing,
+				legacyService:            notServing,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := datastore.NewDatastore()
+			test.setup(ds)
+			s := &healthServer{
+				logger:                    logutil.NewTestLogger(),
+				pools:                     datastore.Pools{{Namespace: "default", Name: "pool"}: ds},
+				metricsStalenessThreshold: testStalenessThreshold,
+				shuttingDown:              func() bool { return test.shuttingDown },
+			}
+			for service, want := range test.want {
+				resp, err := s.Check(context.Background(), &healthPb.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("Unexpected error checking service %q: %v", service, err)
+				}
+				if resp.Status != want {
+					t.Errorf("Unexpected status of service %q, got %v, want %v", service, resp.Status, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthCheckMultiplePools(t *testing.T) {
+	now := time.Now()
+	ready, empty := datastore.NewDatastore(), datastore.NewDatastore()
+	ready.PoolSet(testPool)
+	ready.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+	ready.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: now})
+	empty.PoolSet(&v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"}})
+	s := &healthServer{
+		logger: logutil.NewTestLogger(),
+		pools: datastore.Pools{
+			{Namespace: "default", Name: "pool"}:  ready,
+			{Namespace: "default", Name: "empty"}: empty,
+		},
+		metricsStalenessThreshold: testStalenessThreshold,
+	}
+
+	// A pool scaled to zero does not make the EPP unready for the other pools.
+	want := map[string]healthPb.HealthCheckResponse_ServingStatus{
+		readinessService:                             healthPb.HealthCheckResponse_SERVING,
+		testPoolReadinessService:                     healthPb.HealthCheckResponse_SERVING,
+		poolReadinessServicePrefix + "default/empty": healthPb.HealthCheckResponse_NOT_SERVING,
+	}
+	for service, want := range want {
+		resp, err := s.Check(context.Background(), &healthPb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Unexpected error checking service %q: %v", service, err)
+		}
+		if resp.Status != want {
+			t.Errorf("Unexpected status of service %q, got %v, want %v", service, resp.Status, want)
+		}
+	}
+
+	// Without a schedulable pod in any pool, the EPP is not ready.
+	ready.PodDelete(testPod)
+	for _, service := range []string{readinessService, extProcService} {
+		resp, err := s.Check(context.Background(), &healthPb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Unexpected error checking service %q: %v", service, err)
+		}
+		if resp.Status != healthPb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Unexpected status of service %q, got %v, want %v", service, resp.Status, healthPb.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+}
+
+func TestHealthCheckStalenessThreshold(t *testing.T) {
+	ds := datastore.NewDatastore()
+	ds.PoolSet(testPool)
+	ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+	ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: time.Now().Add(-30 * time.Second)})
+	threshold := 10 * time.Second
+	s := &healthServer{
+		logger:                    logutil.NewTestLogger(),
+		pools:                     datastore.Pools{{Namespace: "default", Name: "pool"}: ds},
+		metricsStalenessThreshold: func(types.NamespacedName) time.Duration { return threshold },
+	}
+
+	// The threshold is read on every check, e.g. after the pool overrides it.
+	for _, want := range []healthPb.HealthCheckResponse_ServingStatus{
+		healthPb.HealthCheckResponse_NOT_SERVING, healthPb.HealthCheckResponse_SERVING,
+	} {
+		resp, err := s.Check(context.Background(), &healthPb.HealthCheckRequest{Service: readinessService})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Status != want {
+			t.Errorf("Unexpected status with threshold %v, got %v, want %v", threshold, resp.Status, want)
+		}
+		threshold = time.Minute
+	}
+}
+
+func TestHealthCheckUnknownService(t *testing.T) {
+	s := &healthServer{logger: logutil.NewTestLogger(), pools: datastore.Pools{}}
+	for _, service := range []string{"unknown", poolReadinessServicePrefix + "default/missing", poolReadinessServicePrefix + "missing"} {
+		_, err := s.Check(context.Background(), &healthPb.HealthCheckRequest{Service: service})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Unexpected error checking service %q, got %v, want code %v", service, err, codes.NotFound)
+		}
+	}
+}
+
+type fakeWatchServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *healthPb.HealthCheckResponse
+}
+
+func (s *fakeWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchServer) Send(resp *healthPb.HealthCheckResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func TestHealthWatch(t *testing.T) {
+	ds := datastore.NewDatastore()
+	s := &healthServer{
+		logger:                    logutil.NewTestLogger(),
+		pools:                     datastore.Pools{{Namespace: "default", Name: "pool"}: ds},
+		metricsStalenessThreshold: testStalenessThreshold,
+		watchInterval:             10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := &fakeWatchServer{ctx: ctx, responses: make(chan *healthPb.HealthCheckResponse, 10)}
+	done := make(chan error)
+	go func() {
+		done <- s.Watch(&healthPb.HealthCheckRequest{Service: extProcService}, srv)
+	}()
+
+	expectStatus := func(want healthPb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		select {
+		case resp := <-srv.responses:
+			if resp.Status != want {
+				t.Fatalf("Unexpected status, got %v, want %v", resp.Status, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for status %v", want)
+		}
+	}
+	expectStatus(healthPb.HealthCheckResponse_NOT_SERVING)
+
+	// Unchanged statuses are not sent again, the synced pool has no schedulable pod yet.
+	ds.PoolSet(testPool)
+	time.Sleep(5 * s.watchInterval)
+	if len(srv.responses) != 0 {
+		t.Errorf("Unexpected status sent %v", <-srv.responses)
+	}
+
+	ds.PodUpdateOrAddEndpointIfNotExist(testPod, "10.0.0.1", false)
+	ds.PodUpdateMetricsIfExist(testPod, &datastore.Metrics{UpdateTime: time.Now()})
+	expectStatus(healthPb.HealthCheckResponse_SERVING)
+
+	cancel()
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("Unexpected error, got %v, want code %v", err, codes.Canceled)
+	}
+}
+
+func TestHealthWatchUnknownService(t *testing.T) {
+	s := &healthServer{logger: logutil.NewTestLogger(), pools: datastore.Pools{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := &fakeWatchServer{ctx: ctx, responses: make(chan *healthPb.HealthCheckResponse, 1)}
+	cancel()
+	if err := s.Watch(&healthPb.HealthCheckRequest{Service: "unknown"}, srv); status.Code(err) != codes.Canceled {
+		t.Errorf("Unexpected error, got %v, want code %v", err, codes.Canceled)
+	}
+	if resp := <-srv.responses; resp.Status != healthPb.HealthCheckResponse_SERVICE_UNKNOWN {
+		t.Errorf("Unexpected status, got %v, want %v", resp.Status, healthPb.HealthCheckResponse_SERVICE_UNKNOWN)
+	}
+}
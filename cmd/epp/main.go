@@ -17,12 +17,18 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -30,27 +36,47 @@ import (
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/openai"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/vllm"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/debug"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
 	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/standalone"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/webhook"
 )
 
 const (
 	defaultMetricsEndpoint = "/metrics"
+	tracingShutdownTimeout = 5 * time.Second
+	// healthShutdownTimeout is how long the health watches are given to complete on shutdown.
+	healthShutdownTimeout = time.Second
+	// shutdownMargin is added to the shutdown of the ext_proc server, for the other runnables to
+	// stop.
+	shutdownMargin = 5 * time.Second
 )
 
 var (
@@ -71,11 +97,23 @@ var (
 	poolName = flag.String(
 		"poolName",
 		runserver.DefaultPoolName,
-		"Name of the InferencePool this Endpoint Picker is associated with.")
+		"Comma-separated list of the InferencePools this Endpoint Picker is associated with, each either "+
+			"<namespace>/<name> or <name> in the --poolNamespace namespace.")
 	poolNamespace = flag.String(
 		"poolNamespace",
 		runserver.DefaultPoolNamespace,
-		"Namespace of the InferencePool this Endpoint Picker is associated with.")
+		"Default namespace of the InferencePools this Endpoint Picker is associated with.")
+	poolSelectorHeader = flag.String(
+		"poolSelectorHeader",
+		runserver.DefaultPoolSelectorHeader,
+		"Header key used to select the InferencePool of a request if multiple pools are served. The pool can also be "+
+			"selected by the "+handlers.PoolMetadataNamespace+" "+handlers.PoolMetadataKey+" Envoy filter metadata.")
+	endpointDiscovery = flag.String(
+		"endpointDiscovery",
+		runserver.DefaultEndpointDiscovery,
+		"How the model server pods of the pools are discovered: "+runserver.EndpointDiscoveryPods+" watches the pods "+
+			"selected by the pools, "+runserver.EndpointDiscoveryEndpointSlices+" watches the EndpointSlices of the Services "+
+			"backing the pools, named by the "+datastore.ServiceAnnotation+" annotation and defaulting to the pool name.")
 	refreshMetricsInterval = flag.Duration(
 		"refreshMetricsInterval",
 		runserver.DefaultRefreshMetricsInterval,
@@ -84,13 +122,131 @@ var (
 		"refreshPrometheusMetricsInterval",
 		runserver.DefaultRefreshPrometheusMetricsInterval,
 		"interval to flush prometheus metrics")
+	metricsStalenessThreshold = flag.Duration(
+		"metricsStalenessThreshold",
+		runserver.DefaultMetricsStalenessThreshold,
+		"maximum age of pod metrics before they are considered stale. Set to 0 to disable staleness handling.")
+	stalenessPolicy = flag.String(
+		"stalenessPolicy",
+		string(scheduling.DefaultStalenessPolicy),
+		"How pods with stale metrics are scheduled. One of Exclude, Penalize (only used if no pod has fresh metrics) "+
+			"or AssumeFullyLoaded.")
+	metricsRateWindow = flag.Duration(
+		"metricsRateWindow",
+		vllm.DefaultRateWindow,
+		"The window over which token rates and latency quantiles are derived from model server counters and histograms.")
+	discoverModels = flag.Bool(
+		"discoverModels",
+		false,
+		"Periodically discover the base models and adapters served by each pod via the OpenAI "+
+			openai.ModelsPath+" endpoint, and only schedule requests to pods serving the target model.")
+	refreshModelsInterval = flag.Duration(
+		"refreshModelsInterval",
+		runserver.DefaultRefreshModelsInterval,
+		"interval to discover the models served by each pod")
+	poolStatusInterval = flag.Duration(
+		"poolStatusInterval",
+		runserver.DefaultPoolStatusInterval,
+		"Interval to update the Ready condition of the InferencePools from the state of the EPP. It is only "+
+			"written by the leader, see --leaderElection. Set to 0 to not write the condition.")
+	poolStatusMinReadyPods = flag.Int(
+		"poolStatusMinReadyPods",
+		runserver.DefaultPoolStatusMinReadyPods,
+		"Minimum number of ready pods, not counting draining pods, for an InferencePool to be Ready.")
+	poolStatusMinFreshMetricsRatio = flag.Float64(
+		"poolStatusMinFreshMetricsRatio",
+		runserver.DefaultPoolStatusMinFreshMetricsRatio,
+		"Minimum share of the ready pods with metrics fresher than --metricsStalenessThreshold for an "+
+			"InferencePool to be Ready, between 0 and 1.")
+	scrapeScheme = flag.String(
+		"scrapeScheme",
+		"http",
+		"The URL scheme used to scrape model server metrics, one of http or https. Can be overridden per pool with the "+
+			datastore.MetricsSchemeAnnotation+" annotation.")
+	scrapeCAFile = flag.String(
+		"scrapeCAFile", "", "The path to a PEM encoded CA bundle used to verify the model server certificates when scraping metrics.")
+	scrapeCertFile = flag.String(
+		"scrapeCertFile", "", "The path to a PEM encoded client certificate presented to the model servers when scraping metrics.")
+	scrapeKeyFile = flag.String(
+		"scrapeKeyFile", "", "The path to the PEM encoded private key of the client certificate used when scraping metrics.")
+	scrapeInsecureSkipVerify = flag.Bool(
+		"scrapeInsecureSkipVerify", false, "Skip the verification of the model server certificates when scraping metrics.")
+	scrapeBearerTokenFile = flag.String(
+		"scrapeBearerTokenFile", "", "The path to a file containing a bearer token sent to the model servers when scraping "+
+			"metrics. The file is re-read when it changes.")
 	logVerbosity  = flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
 	secureServing = flag.Bool(
 		"secureServing", runserver.DefaultSecureServing, "Enables secure serving. Defaults to true.")
 	certPath = flag.String(
 		"certPath", "", "The path to the certificate for secure serving. The certificate and private key files "+
 			"are assumed to be named tls.crt and tls.key, respectively. If not set, and secureServing is enabled, "+
-			"then a self-signed certificate is used.")
+			"then a self-signed certificate is used. The files are reloaded when they change, e.g. when the "+
+			"certificate is rotated.")
+	clientCAFile = flag.String(
+		"clientCAFile", "", "The path to the CA bundle verifying the client certificates of secure serving. If set, "+
+			"only clients presenting a certificate signed by the bundle, e.g. the gateway, can call the EPP. The file "+
+			"is reloaded when it changes.")
+	leaderElection = flag.Bool(
+		"leaderElection", true, "Enables leader election between the EPP replicas. All replicas serve requests, "+
+			"but only the leader writes the status of the InferenceModels and InferencePools. Only disable it when "+
+			"running a single replica, otherwise every replica writes the status.")
+	leaderElectionID = flag.String(
+		"leaderElectionID", "", "The name of the Lease used for leader election, in the namespace of the first pool "+
+			"of --poolName. Defaults to a name derived from the served pools.")
+	cachePodsByPoolSelector = flag.Bool(
+		"cachePodsByPoolSelector", runserver.DefaultCachePodsByPoolSelector, "Restricts the pods cached by the EPP "+
+			"to the pods selected by the pools, if all pools of a namespace exist at startup and share the same "+
+			"selector. The EPP exits to be restarted if the selector of such a pool changes.")
+	webhookPort = flag.Int(
+		"webhookPort", 0, "The port of the validating admission and CRD conversion webhooks of the InferenceModels and "+
+			"InferencePools. The webhooks are disabled if 0.")
+	webhookCertDir = flag.String(
+		"webhookCertDir", "", "The directory containing the tls.crt and tls.key files of the webhook server. Defaults "+
+			"to the controller-runtime default directory.")
+	standaloneConfig = flag.String(
+		"standaloneConfig", "", "The path to a YAML file defining the InferencePools, InferenceModels and model server "+
+			"endpoints. If set, the EPP runs without Kubernetes and watches the file for changes instead of the API server.")
+	standaloneConfigRefreshInterval = flag.Duration(
+		"standaloneConfigRefreshInterval",
+		standalone.DefaultRefreshInterval,
+		"Interval to check the --standaloneConfig file for changes.")
+	configFile = flag.String(
+		"configFile", "", "The path to a YAML file of kind "+config.Kind+" configuring the scheduling, metrics and pool "+
+			"status settings. Flags that are set explicitly take precedence over the file. Changes of the scheduling "+
+			"settings are applied at runtime, other changes require a restart.")
+	debugPort = flag.Int(
+		"debugPort", 0, "The port of the debug HTTP API exposing the pods, models and pools as seen by the EPP, and "+
+			"a dry-run of the scheduling of a request. Requests are authenticated and authorized against the API "+
+			"server like the metrics endpoint, unless --debugTokenFile is set. The API is disabled if 0.")
+	debugTokenFile = flag.String(
+		"debugTokenFile", "", "The path to a file containing the bearer token required by the debug HTTP API. "+
+			"The file is re-read when it changes. Required with --standaloneConfig.")
+	decisionLogSize = flag.Int(
+		"decisionLogSize", scheduling.DefaultDecisionLogSize, "The number of recent scheduling decisions kept for "+
+			"inspection with the "+debug.DecisionsPath+" endpoint of the debug HTTP API. Set to 0 to not keep decisions.")
+	decisionLogFile = flag.String(
+		"decisionLogFile", "", "The path to a file the scheduling decisions are appended to as JSON lines for offline "+
+			"analysis. Decisions are not written if not set.")
+	configRefreshInterval = flag.Duration(
+		"configRefreshInterval",
+		config.DefaultRefreshInterval,
+		"Interval to check the --configFile file for changes.")
+	shutdownDrainPeriod = flag.Duration(
+		"shutdownDrainPeriod", runserver.DefaultShutdownDrainPeriod, "How long the ext-proc server keeps accepting "+
+			"new requests on shutdown, once it is reported as not ready by the readiness and ext-proc health services.")
+	shutdownTimeout = flag.Duration(
+		"shutdownTimeout", runserver.DefaultShutdownTimeout, "How long the in-flight requests are given to complete "+
+			"after --shutdownDrainPeriod, before they are cancelled. The sum of both should not exceed the termination "+
+			"grace period of the pod.")
+	tracingEndpoint = flag.String(
+		"tracingEndpoint", "", "The address of the OpenTelemetry collector the request spans are exported to with "+
+			"OTLP over gRPC. Spans are not exported if not set, the W3C trace context of the requests is still "+
+			"propagated to the model servers.")
+	tracingInsecure = flag.Bool(
+		"tracingInsecure", false, "Disables the transport security of the connection to --tracingEndpoint.")
+	tracingSamplingRatio = flag.Float64(
+		"tracingSamplingRatio", 1, "The ratio of the requests traced, between 0 and 1, if the trace of the "+
+			"request is not sampled already by the client or the gateway.")
 
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -99,6 +255,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1alpha2.AddToScheme(scheme))
 }
 
 func main() {
@@ -120,6 +277,11 @@ func run() error {
 		setupLog.Error(err, "Failed to validate flags")
 		return err
 	}
+	poolNames, err := parsePoolNames(*poolName, *poolNamespace)
+	if err != nil {
+		setupLog.Error(err, "Failed to parse pool names")
+		return err
+	}
 
 	// Print all flag values
 	flags := make(map[string]any)
@@ -128,41 +290,147 @@ func run() error {
 	})
 	setupLog.Info("Flags processed", "flags", flags)
 
-	// Init runtime.
-	cfg, err := ctrl.GetConfig()
+	eppConfig, eppConfigData, err := config.Load(*configFile, applyFlagOverrides)
 	if err != nil {
-		setupLog.Error(err, "Failed to get rest config")
+		setupLog.Error(err, "Failed to load config", "path", *configFile)
 		return err
 	}
+	setupLog.Info("Config loaded", "config", eppConfig)
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: scheme})
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:      *tracingEndpoint,
+		Insecure:      *tracingInsecure,
+		SamplingRatio: *tracingSamplingRatio,
+	})
 	if err != nil {
-		setupLog.Error(err, "Failed to create controller manager", "config", cfg)
+		setupLog.Error(err, "Failed to set up tracing")
+		return err
+	}
+	defer func() {
+		// Flush the spans of the last requests.
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "Failed to shut down tracing")
+		}
+	}()
+
+	scrapeClient, err := backend.NewScrapeHTTPClient(backend.ScrapeTransportConfig{
+		CAFile:             *scrapeCAFile,
+		CertFile:           *scrapeCertFile,
+		KeyFile:            *scrapeKeyFile,
+		InsecureSkipVerify: *scrapeInsecureSkipVerify,
+		BearerTokenFile:    *scrapeBearerTokenFile,
+	})
+	if err != nil {
+		setupLog.Error(err, "Failed to create metrics scrape client")
 		return err
 	}
 
 	// Setup runner.
-	datastore := datastore.NewDatastore()
-	provider := backend.NewProvider(&vllm.PodMetricsClientImpl{}, datastore)
 	serverRunner := &runserver.ExtProcServerRunner{
 		GrpcPort:                         *grpcPort,
 		TargetEndpointKey:                *targetEndpointKey,
-		PoolName:                         *poolName,
-		PoolNamespace:                    *poolNamespace,
-		RefreshMetricsInterval:           *refreshMetricsInterval,
-		RefreshPrometheusMetricsInterval: *refreshPrometheusMetricsInterval,
-		Datastore:                        datastore,
+		PoolSelectorHeader:               *poolSelectorHeader,
+		EndpointDiscovery:                *endpointDiscovery,
+		RefreshMetricsInterval:           eppConfig.Metrics.RefreshInterval.Duration,
+		RefreshPrometheusMetricsInterval: eppConfig.Metrics.PrometheusRefreshInterval.Duration,
+		MetricsStalenessThreshold:        eppConfig.Metrics.StalenessThreshold.Duration,
+		SchedulerConfig:                  eppConfig.SchedulerConfig(),
+		RefreshModelsInterval:            eppConfig.Metrics.RefreshModelsInterval.Duration,
+		PoolStatusInterval:               eppConfig.PoolStatus.Interval.Duration,
+		PoolStatusMinReadyPods:           *eppConfig.PoolStatus.MinReadyPods,
+		PoolStatusMinFreshMetricsRatio:   *eppConfig.PoolStatus.MinFreshMetricsRatio,
 		SecureServing:                    *secureServing,
 		CertPath:                         *certPath,
-		Provider:                         provider,
+		ClientCAFile:                     *clientCAFile,
+		ShutdownDrainPeriod:              *shutdownDrainPeriod,
+		ShutdownTimeout:                  *shutdownTimeout,
+		CachePodsByPoolSelector:          *cachePodsByPoolSelector,
+	}
+	decisionLog, decisionSink := setupDecisionRecorders(serverRunner)
+	for _, name := range poolNames {
+		// Each pool has its own metrics client, as token rates are tracked per client.
+		ds := datastore.NewDatastore()
+		pool := &runserver.Pool{
+			NamespacedName: name,
+			Datastore:      ds,
+			Provider: backend.NewProvider(&vllm.PodMetricsClientImpl{
+				Client:     scrapeClient,
+				Scheme:     eppConfig.Metrics.ScrapeScheme,
+				RateWindow: eppConfig.Metrics.RateWindow.Duration,
+			}, ds),
+		}
+		if *eppConfig.Metrics.DiscoverModels {
+			pool.ModelProber = backend.NewModelProber(&openai.ModelsClientImpl{
+				Client: scrapeClient,
+				Scheme: eppConfig.Metrics.ScrapeScheme,
+			}, ds)
+		}
+		serverRunner.Pools = append(serverRunner.Pools, pool)
+	}
+
+	if *standaloneConfig != "" {
+		return runStandalone(serverRunner, eppConfig, eppConfigData, decisionLog, decisionSink)
 	}
+
+	// Init runtime.
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		setupLog.Error(err, "Failed to get rest config")
+		return err
+	}
+	ctx := ctrl.SetupSignalHandler()
+
+	// Only the namespaces of the pools are cached, the pools are read directly to restrict the pods.
+	reader, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "Failed to create client")
+		return err
+	}
+	cacheOpts, err := serverRunner.CacheOptions(ctx, reader)
+	if err != nil {
+		setupLog.Error(err, "Failed to compute cache options")
+		return err
+	}
+	for namespace, selector := range serverRunner.PodCacheSelectors {
+		setupLog.Info("Restricting cached pods", "namespace", namespace, "selector", selector.String())
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                        scheme,
+		Cache:                         cacheOpts,
+		LeaderElection:                *leaderElection,
+		LeaderElectionID:              leaderElectionName(poolNames),
+		LeaderElectionNamespace:       poolNames[0].Namespace,
+		LeaderElectionReleaseOnCancel: true,
+		// Leave time for the ext-proc server to drain the in-flight requests.
+		GracefulShutdownTimeout: ptr.To(*shutdownDrainPeriod + *shutdownTimeout + shutdownMargin),
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Port:    *webhookPort,
+			CertDir: *webhookCertDir,
+		}),
+	})
+	if err != nil {
+		setupLog.Error(err, "Failed to create controller manager", "config", cfg)
+		return err
+	}
+
 	if err := serverRunner.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to setup ext-proc server")
 		return err
 	}
 
+	// Register the validating and conversion webhooks. The webhook server only starts if webhooks are registered.
+	if *webhookPort != 0 {
+		if err := webhook.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to setup webhooks")
+			return err
+		}
+	}
+
 	// Register health server.
-	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), datastore, *grpcHealthPort); err != nil {
+	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), serverRunner, *grpcHealthPort); err != nil {
 		return err
 	}
 
@@ -177,9 +445,27 @@ func run() error {
 		return err
 	}
 
+	// Register config file watcher.
+	if err := registerConfigWatcher(mgr, serverRunner, eppConfig, eppConfigData); err != nil {
+		return err
+	}
+
+	// Register debug handler.
+	if err := registerDebugHandler(mgr, serverRunner, decisionLog, cfg); err != nil {
+		return err
+	}
+
+	// Register scheduling decision file writer.
+	if decisionSink != nil {
+		if err := mgr.Add(runnable.NoLeaderElection(decisionSink)); err != nil {
+			setupLog.Error(err, "Failed to register scheduling decision file writer")
+			return err
+		}
+	}
+
 	// Start the manager. This blocks until a signal is received.
 	setupLog.Info("Controller manager starting")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Error starting controller manager")
 		return err
 	}
@@ -187,6 +473,79 @@ func run() error {
 	return nil
 }
 
+// leaderElectionName returns the --leaderElectionID, or a name derived from the given pools, so
+// that EPPs serving different pools do not share a Lease.
+func leaderElectionName(poolNames []types.NamespacedName) string {
+	if *leaderElectionID != "" {
+		return *leaderElectionID
+	}
+	names := make([]string, 0, len(poolNames))
+	for _, name := range poolNames {
+		names = append(names, name.String())
+	}
+	sort.Strings(names)
+	hash := sha256.Sum256([]byte(strings.Join(names, ",")))
+	return "epp-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// runStandalone runs the EPP without Kubernetes, populating the datastores from the
+// --standaloneConfig file instead of the reconcilers.
+func runStandalone(serverRunner *runserver.ExtProcServerRunner, eppConfig *config.EndpointPickerConfig, eppConfigData []byte,
+	decisionLog *scheduling.DecisionLog, decisionSink *scheduling.DecisionFileSink) error {
+	group := &runnable.Group{}
+	if err := group.Add(&standalone.Watcher{
+		Path:            *standaloneConfig,
+		Pools:           serverRunner.Datastores(),
+		RefreshInterval: *standaloneConfigRefreshInterval,
+	}); err != nil {
+		setupLog.Error(err, "Failed to register standalone config watcher")
+		return err
+	}
+
+	// Register health server.
+	if err := registerHealthServer(group, ctrl.Log.WithName("health"), serverRunner, *grpcHealthPort); err != nil {
+		return err
+	}
+
+	// Register ext-proc server.
+	if err := group.Add(serverRunner.AsRunnable(ctrl.Log.WithName("ext-proc"))); err != nil {
+		setupLog.Error(err, "Failed to register ext-proc server")
+		return err
+	}
+
+	// Register metrics handler, without authentication as there is no API server to delegate to.
+	if err := registerMetricsHandler(group, *metricsPort, nil); err != nil {
+		return err
+	}
+
+	// Register config file watcher.
+	if err := registerConfigWatcher(group, serverRunner, eppConfig, eppConfigData); err != nil {
+		return err
+	}
+
+	// Register debug handler, authenticated by --debugTokenFile as there is no API server to delegate to.
+	if err := registerDebugHandler(group, serverRunner, decisionLog, nil); err != nil {
+		return err
+	}
+
+	// Register scheduling decision file writer.
+	if decisionSink != nil {
+		if err := group.Add(decisionSink); err != nil {
+			setupLog.Error(err, "Failed to register scheduling decision file writer")
+			return err
+		}
+	}
+
+	// Start the runnables. This blocks until a signal is received.
+	setupLog.Info("Standalone EPP starting", "config", *standaloneConfig)
+	if err := group.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "Error running standalone EPP")
+		return err
+	}
+	setupLog.Info("Standalone EPP terminated")
+	return nil
+}
+
 func initLogging(opts *zap.Options) {
 	// Unless -zap-log-level is explicitly set, use -v
 	useV := true
@@ -205,29 +564,52 @@ func initLogging(opts *zap.Options) {
 	ctrl.SetLogger(logger)
 }
 
+// runnableAdder is implemented by the controller manager, and by runnable.Group in standalone mode.
+type runnableAdder interface {
+	Add(manager.Runnable) error
+}
+
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
-func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, port int) error {
+func registerHealthServer(mgr runnableAdder, logger logr.Logger, serverRunner *runserver.ExtProcServerRunner, port int) error {
 	srv := grpc.NewServer()
+	// The staleness threshold is read from the schedulers, so that the readiness follows the
+	// threshold the requests are scheduled with, including the overrides of the pools.
+	schedulers := serverRunner.Schedulers()
+	configCtx := ctrl.LoggerInto(context.Background(), logger)
 	healthPb.RegisterHealthServer(srv, &healthServer{
-		logger:    logger,
-		datastore: ds,
+		logger: logger,
+		pools:  serverRunner.Datastores(),
+		metricsStalenessThreshold: func(pool types.NamespacedName) time.Duration {
+			return schedulers[pool].Config(configCtx).MetricsStalenessThreshold
+		},
+		shuttingDown: serverRunner.ShuttingDown,
 	})
-	if err := mgr.Add(
-		runnable.NoLeaderElection(runnable.GRPCServer("health", srv, port))); err != nil {
+	// The health server keeps serving during the drain period of the ext_proc server, for the
+	// clients to observe that it is not ready anymore. The watches are cancelled after.
+	if err := mgr.Add(runnable.NoLeaderElection(runnable.GRPCServerWithShutdown("health", srv, port, runnable.GRPCShutdown{
+		DrainPeriod: serverRunner.ShutdownDrainPeriod,
+		Timeout:     healthShutdownTimeout,
+	}))); err != nil {
 		setupLog.Error(err, "Failed to register health server")
 		return err
 	}
 	return nil
 }
 
-// registerMetricsHandler adds the metrics HTTP handler as a Runnable to the given manager.
-func registerMetricsHandler(mgr manager.Manager, port int, cfg *rest.Config) error {
+// registerMetricsHandler adds the metrics HTTP handler as a Runnable to the given manager. The
+// handler requires authentication and authorization against the API server of the given config,
+// unless the config is nil.
+func registerMetricsHandler(mgr runnableAdder, port int, cfg *rest.Config) error {
 	metrics.Register()
 
 	// Init HTTP server.
-	h, err := metricsHandlerWithAuthenticationAndAuthorization(cfg)
-	if err != nil {
-		return err
+	var h http.Handler = promhttp.HandlerFor(legacyregistry.DefaultGatherer, promhttp.HandlerOpts{})
+	if cfg != nil {
+		var err error
+		h, err = withAuthenticationAndAuthorization(cfg, "metrics", defaultMetricsEndpoint, h)
+		if err != nil {
+			return err
+		}
 	}
 
 	mux := http.NewServeMux()
@@ -248,29 +630,108 @@ func registerMetricsHandler(mgr manager.Manager, port int, cfg *rest.Config) err
 	return nil
 }
 
-func metricsHandlerWithAuthenticationAndAuthorization(cfg *rest.Config) (http.Handler, error) {
-	h := promhttp.HandlerFor(
-		legacyregistry.DefaultGatherer,
-		promhttp.HandlerOpts{},
+// registerConfigWatcher adds a Runnable applying the changes of the --configFile file to the given
+// manager, if the file is set. Changes are detected against eppConfigData, the content the config
+// in effect was loaded from.
+func registerConfigWatcher(mgr runnableAdder, serverRunner *runserver.ExtProcServerRunner, eppConfig *config.EndpointPickerConfig, eppConfigData []byte) error {
+	if *configFile == "" {
+		return nil
+	}
+	if err := mgr.Add(runnable.NoLeaderElection(&config.Watcher{
+		Path:            *configFile,
+		RefreshInterval: *configRefreshInterval,
+		Config:          eppConfig,
+		Data:            eppConfigData,
+		Overrides:       applyFlagOverrides,
+		Apply: func(cfg *config.EndpointPickerConfig) {
+			serverRunner.UpdateSchedulerConfig(cfg.SchedulerConfig())
+		},
+	})); err != nil {
+		setupLog.Error(err, "Failed to register config file watcher")
+		return err
+	}
+	return nil
+}
+
+// setupDecisionRecorders sets the recorders of the scheduling decisions of the runner from the
+// --decisionLogSize and --decisionLogFile flags, and returns them. The returned file sink must be
+// started to open the file and write the decisions.
+func setupDecisionRecorders(serverRunner *runserver.ExtProcServerRunner) (*scheduling.DecisionLog, *scheduling.DecisionFileSink) {
+	var (
+		recorders scheduling.DecisionRecorders
+		log       *scheduling.DecisionLog
+		sink      *scheduling.DecisionFileSink
 	)
+	if *decisionLogSize > 0 {
+		log = scheduling.NewDecisionLog(*decisionLogSize)
+		recorders = append(recorders, log)
+	}
+	if *decisionLogFile != "" {
+		sink = scheduling.NewDecisionFileSink(*decisionLogFile, 0)
+		recorders = append(recorders, sink)
+	}
+	if len(recorders) > 0 {
+		serverRunner.DecisionRecorder = recorders
+	}
+	return log, sink
+}
+
+// registerDebugHandler adds the debug HTTP handler as a Runnable to the given manager, if
+// --debugPort is set. The handler requires the --debugTokenFile bearer token if set, otherwise
+// authentication and authorization against the API server of the given config.
+func registerDebugHandler(mgr runnableAdder, serverRunner *runserver.ExtProcServerRunner, decisionLog *scheduling.DecisionLog, cfg *rest.Config) error {
+	if *debugPort == 0 {
+		return nil
+	}
+	pools := map[types.NamespacedName]*debug.Pool{}
+	for name, scheduler := range serverRunner.Schedulers() {
+		pools[name] = &debug.Pool{Datastore: serverRunner.Datastores()[name], Scheduler: scheduler}
+	}
+	h := debug.NewHandler(pools, decisionLog)
+	var err error
+	if *debugTokenFile != "" {
+		if h, err = debug.WithBearerTokenFile(h, *debugTokenFile); err != nil {
+			setupLog.Error(err, "Failed to read debug token file")
+			return err
+		}
+	} else if h, err = withAuthenticationAndAuthorization(cfg, "debug", "/debug", h); err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:    net.JoinHostPort("", strconv.Itoa(*debugPort)),
+		Handler: h,
+	}
+	if err := mgr.Add(&manager.Server{
+		Name:   "debug",
+		Server: srv,
+	}); err != nil {
+		setupLog.Error(err, "Failed to register debug HTTP handler")
+		return err
+	}
+	return nil
+}
+
+// withAuthenticationAndAuthorization returns a handler authenticating and authorizing the requests
+// to h against the API server of the given config.
+func withAuthenticationAndAuthorization(cfg *rest.Config, name, path string, h http.Handler) (http.Handler, error) {
 	httpClient, err := rest.HTTPClientFor(cfg)
 	if err != nil {
-		setupLog.Error(err, "Failed to create http client for metrics auth")
+		setupLog.Error(err, "Failed to create http client for auth", "name", name)
 		return nil, err
 	}
 
 	filter, err := filters.WithAuthenticationAndAuthorization(cfg, httpClient)
 	if err != nil {
-		setupLog.Error(err, "Failed to create metrics filter for auth")
+		setupLog.Error(err, "Failed to create filter for auth", "name", name)
 		return nil, err
 	}
-	metricsLogger := ctrl.Log.WithName("metrics").WithValues("path", defaultMetricsEndpoint)
-	metricsAuthHandler, err := filter(metricsLogger, h)
+	authHandler, err := filter(ctrl.Log.WithName(name).WithValues("path", path), h)
 	if err != nil {
-		setupLog.Error(err, "Failed to create metrics auth handler")
+		setupLog.Error(err, "Failed to create auth handler", "name", name)
 		return nil, err
 	}
-	return metricsAuthHandler, nil
+	return authHandler, nil
 }
 
 func validateFlags() error {
@@ -278,5 +739,75 @@ func validateFlags() error {
 		return fmt.Errorf("required %q flag not set", "poolName")
 	}
 
+	if *endpointDiscovery != runserver.EndpointDiscoveryPods && *endpointDiscovery != runserver.EndpointDiscoveryEndpointSlices {
+		return fmt.Errorf("invalid %q flag value %q, must be %s or %s", "endpointDiscovery", *endpointDiscovery,
+			runserver.EndpointDiscoveryPods, runserver.EndpointDiscoveryEndpointSlices)
+	}
+
+	if *debugPort != 0 && *standaloneConfig != "" && *debugTokenFile == "" {
+		return fmt.Errorf("%q flag is required with %q and %q", "debugTokenFile", "debugPort", "standaloneConfig")
+	}
+
+	if *shutdownDrainPeriod < 0 || *shutdownTimeout < 0 {
+		return fmt.Errorf("%q and %q flags must not be negative", "shutdownDrainPeriod", "shutdownTimeout")
+	}
+
+	if *clientCAFile != "" && !*secureServing {
+		return fmt.Errorf("%q flag requires %q", "clientCAFile", "secureServing")
+	}
+
+	if *tracingSamplingRatio < 0 || *tracingSamplingRatio > 1 {
+		return fmt.Errorf("invalid %q flag value %v, must be between 0 and 1", "tracingSamplingRatio", *tracingSamplingRatio)
+	}
+
 	return nil
 }
+
+// applyFlagOverrides sets the settings of the config that are set explicitly by flags, so that
+// they take precedence over the --configFile file. The values are validated with the config.
+func applyFlagOverrides(cfg *config.EndpointPickerConfig) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "stalenessPolicy":
+			cfg.Scheduling.StalenessPolicy = scheduling.StalenessPolicy(*stalenessPolicy)
+		case "refreshMetricsInterval":
+			cfg.Metrics.RefreshInterval = &metav1.Duration{Duration: *refreshMetricsInterval}
+		case "refreshPrometheusMetricsInterval":
+			cfg.Metrics.PrometheusRefreshInterval = &metav1.Duration{Duration: *refreshPrometheusMetricsInterval}
+		case "metricsStalenessThreshold":
+			cfg.Metrics.StalenessThreshold = &metav1.Duration{Duration: *metricsStalenessThreshold}
+		case "metricsRateWindow":
+			cfg.Metrics.RateWindow = &metav1.Duration{Duration: *metricsRateWindow}
+		case "scrapeScheme":
+			cfg.Metrics.ScrapeScheme = *scrapeScheme
+		case "discoverModels":
+			cfg.Metrics.DiscoverModels = ptr.To(*discoverModels)
+		case "refreshModelsInterval":
+			cfg.Metrics.RefreshModelsInterval = &metav1.Duration{Duration: *refreshModelsInterval}
+		case "poolStatusInterval":
+			cfg.PoolStatus.Interval = &metav1.Duration{Duration: *poolStatusInterval}
+		case "poolStatusMinReadyPods":
+			cfg.PoolStatus.MinReadyPods = ptr.To(*poolStatusMinReadyPods)
+		case "poolStatusMinFreshMetricsRatio":
+			cfg.PoolStatus.MinFreshMetricsRatio = ptr.To(*poolStatusMinFreshMetricsRatio)
+		}
+	})
+}
+
+// parsePoolNames parses the comma-separated list of pools of the --poolName flag.
+func parsePoolNames(refs, defaultNamespace string) ([]types.NamespacedName, error) {
+	var names []types.NamespacedName
+	seen := map[types.NamespacedName]bool{}
+	for _, ref := range strings.Split(refs, ",") {
+		name, err := datastore.ParsePoolName(strings.TrimSpace(ref), defaultNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate InferencePool %q", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
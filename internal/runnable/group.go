@@ -0,0 +1,45 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Group runs Runnables without a controller manager, e.g. when the EPP runs without Kubernetes.
+// Like the manager, it stops all Runnables once one of them fails.
+type Group struct {
+	runnables []manager.Runnable
+}
+
+// Add adds a Runnable to the group. It must be called before Start.
+func (g *Group) Add(r manager.Runnable) error {
+	g.runnables = append(g.runnables, r)
+	return nil
+}
+
+// Start runs all Runnables until the context is done or one of them fails, and waits for all of
+// them to return. It returns the errors of the failed Runnables.
+func (g *Group) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(g.runnables))
+	for _, r := range g.runnables {
+		go func() {
+			err := r.Start(ctx)
+			if err != nil {
+				cancel()
+			}
+			errCh <- err
+		}()
+	}
+	var errs []error
+	for range g.runnables {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
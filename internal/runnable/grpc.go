@@ -4,15 +4,38 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// GRPCShutdown configures the shutdown of a gRPC server once its context is done.
+type GRPCShutdown struct {
+	// OnShutdown is called when the shutdown starts, e.g. to report the server as not ready.
+	OnShutdown func()
+	// DrainPeriod is how long the server keeps accepting new streams once the shutdown started,
+	// for the clients to observe that it is not ready anymore.
+	DrainPeriod time.Duration
+	// Timeout is how long the in-flight streams are given to complete once the server stopped
+	// accepting new streams. The remaining streams are cancelled after it. There is no timeout if
+	// not positive.
+	Timeout time.Duration
+	// OnTimeout is called before the remaining streams are cancelled.
+	OnTimeout func()
+}
+
 // GRPCServer converts the given gRPC server into a runnable.
 // The server name is just being used for logging.
 func GRPCServer(name string, srv *grpc.Server, port int) manager.Runnable {
+	return GRPCServerWithShutdown(name, srv, port, GRPCShutdown{})
+}
+
+// GRPCServerWithShutdown converts the given gRPC server into a runnable, shut down as configured
+// once the context is done. The runnable returns once the server is stopped.
+// The server name is just being used for logging.
+func GRPCServerWithShutdown(name string, srv *grpc.Server, port int, shutdown GRPCShutdown) manager.Runnable {
 	return manager.RunnableFunc(func(ctx context.Context) error {
 		// Use "name" key as that is what manager.Server does as well.
 		log := ctrl.Log.WithValues("name", name)
@@ -27,25 +50,60 @@ func GRPCServer(name string, srv *grpc.Server, port int) manager.Runnable {
 
 		log.Info("gRPC server listening", "port", port)
 
-		// Shutdown on context closed.
 		// Terminate the server on context closed.
 		// Make sure the goroutine does not leak.
 		doneCh := make(chan struct{})
-		defer close(doneCh)
+		stoppedCh := make(chan struct{})
 		go func() {
+			defer close(stoppedCh)
 			select {
 			case <-ctx.Done():
-				log.Info("gRPC server shutting down")
-				srv.GracefulStop()
 			case <-doneCh:
+				return
+			}
+
+			log.Info("gRPC server shutting down")
+			if shutdown.OnShutdown != nil {
+				shutdown.OnShutdown()
+			}
+			if shutdown.DrainPeriod > 0 {
+				log.Info("gRPC server draining", "period", shutdown.DrainPeriod)
+				select {
+				case <-time.After(shutdown.DrainPeriod):
+				case <-doneCh:
+					return
+				}
+			}
+			if shutdown.Timeout <= 0 {
+				srv.GracefulStop()
+				return
+			}
+
+			gracefulStopCh := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(gracefulStopCh)
+			}()
+			select {
+			case <-gracefulStopCh:
+			case <-time.After(shutdown.Timeout):
+				log.Info("gRPC server shutdown timed out, cancelling the in-flight streams", "timeout", shutdown.Timeout)
+				if shutdown.OnTimeout != nil {
+					shutdown.OnTimeout()
+				}
+				srv.Stop()
 			}
 		}()
 
 		// Keep serving until terminated.
-		if err := srv.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+		err = srv.Serve(lis)
+		close(doneCh)
+		if err != nil && err != grpc.ErrServerStopped {
 			log.Error(err, "gRPC server failed")
 			return err
 		}
+		// Wait for the in-flight streams.
+		<-stoppedCh
 		log.Info("gRPC server terminated")
 		return nil
 	})
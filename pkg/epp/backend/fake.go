@@ -46,3 +46,16 @@ type FakeDataStore struct {
 func (fds *FakeDataStore) FetchModelData(modelName string) (returnModel *v1alpha1.InferenceModel) {
 	return fds.Res[modelName]
 }
+
+type FakeModelsClient struct {
+	Err map[types.NamespacedName]error
+	Res map[types.NamespacedName]*datastore.ServedModels
+}
+
+func (f *FakeModelsClient) FetchModels(ctx context.Context, pod *datastore.PodMetrics) (*datastore.ServedModels, error) {
+	if err, ok := f.Err[pod.NamespacedName]; ok {
+		return nil, err
+	}
+	log.FromContext(ctx).V(logutil.VERBOSE).Info("Fetching models for pod", "pod", pod.NamespacedName, "new", f.Res[pod.NamespacedName])
+	return f.Res[pod.NamespacedName], nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/multierr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	fetchModelsTimeout = 5 * time.Second
+)
+
+type ModelsClient interface {
+	FetchModels(ctx context.Context, pod *datastore.PodMetrics) (*datastore.ServedModels, error)
+}
+
+func NewModelProber(mc ModelsClient, datastore datastore.Datastore) *ModelProber {
+	return &ModelProber{
+		mc:        mc,
+		datastore: datastore,
+	}
+}
+
+// ModelProber periodically discovers the base models and adapters served by each pod.
+type ModelProber struct {
+	mc        ModelsClient
+	datastore datastore.Datastore
+}
+
+func (p *ModelProber) Init(ctx context.Context, refreshModelsInterval time.Duration) error {
+	logger := log.FromContext(ctx)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.V(logutil.DEFAULT).Info("Shutting down models prober")
+				return
+			default:
+				if err := p.refreshModelsOnce(logger); err != nil {
+					logger.V(logutil.DEFAULT).Error(err, "Failed to refresh served models")
+				}
+				time.Sleep(refreshModelsInterval)
+			}
+		}
+	}()
+	return nil
+}
+
+// refreshModelsOnce discovers the served models of all pods in parallel. A pod whose discovery
+// fails keeps its previously discovered models.
+func (p *ModelProber) refreshModelsOnce(logger logr.Logger) error {
+	loggerTrace := logger.V(logutil.TRACE)
+	ctx, cancel := context.WithTimeout(context.Background(), fetchModelsTimeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	p.datastore.PodRange(func(key, value any) bool {
+		pod := value.(*datastore.PodMetrics)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			served, err := p.mc.FetchModels(ctx, pod)
+			if err != nil {
+				mu.Lock()
+				errs = multierr.Append(errs, fmt.Errorf("failed to discover models of %s: %w", pod.NamespacedName, err))
+				mu.Unlock()
+				return
+			}
+			if served == nil {
+				return
+			}
+			p.datastore.PodUpdateServedModelsIfExist(pod.NamespacedName, served)
+			loggerTrace.Info("Updated served models for pod", "pod", pod.NamespacedName, "servedModels", served)
+		}()
+		return true
+	})
+	wg.Wait()
+	return errs
+}
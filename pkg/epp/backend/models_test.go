@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestModelProber(t *testing.T) {
+	logger := logutil.NewTestLogger()
+	now := time.Now()
+	served1 := &datastore.ServedModels{
+		BaseModels: sets.New("base"),
+		Adapters:   map[string]string{"lora": "base"},
+		UpdateTime: now,
+	}
+	previous2 := datastore.ServedModels{
+		BaseModels: sets.New("other-base"),
+		UpdateTime: now.Add(-time.Minute),
+	}
+
+	pods := populateMap(pod1, pod2)
+	val, _ := pods.Load(pod2.NamespacedName)
+	val.(*datastore.PodMetrics).ServedModels = previous2
+	ds := datastore.NewFakeDatastore(pods, nil, nil)
+
+	p := NewModelProber(&FakeModelsClient{
+		Err: map[types.NamespacedName]error{
+			pod2.NamespacedName: errors.New("injected error"),
+		},
+		Res: map[types.NamespacedName]*datastore.ServedModels{
+			pod1.NamespacedName: served1,
+		},
+	}, ds)
+	err := p.refreshModelsOnce(logger)
+	assert.Error(t, err)
+
+	got1, _ := ds.PodGet(pod1.NamespacedName)
+	assert.Equal(t, *served1, got1.ServedModels)
+	// The pod that failed discovery keeps its previously discovered models.
+	got2, _ := ds.PodGet(pod2.NamespacedName)
+	assert.Equal(t, previous2, got2.ServedModels)
+}
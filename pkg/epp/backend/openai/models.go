@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openai provides model discovery for model servers implementing the OpenAI API.
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	ModelsPath = "/v1/models"
+)
+
+// modelList is the response of the OpenAI list models endpoint.
+type modelList struct {
+	Data []model `json:"data"`
+}
+
+type model struct {
+	ID string `json:"id"`
+	// Parent is set for LoRA adapters and refers to the base model, this is a vLLM extension.
+	Parent *string `json:"parent,omitempty"`
+}
+
+type ModelsClientImpl struct {
+	// Client is used to call the model servers. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// Scheme is the URL scheme used for pods that do not override it. Defaults to "http".
+	Scheme string
+}
+
+// FetchModels lists the models served by the given pod.
+func (c *ModelsClientImpl) FetchModels(ctx context.Context, pod *datastore.PodMetrics) (*datastore.ServedModels, error) {
+	loggerDefault := log.FromContext(ctx).V(logutil.DEFAULT)
+
+	url := pod.BuildEndpoint(c.Scheme, ModelsPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		loggerDefault.Error(err, "Failed create HTTP request", "method", http.MethodGet, "url", url)
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch models from %s: %w", pod.NamespacedName, err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from %s: %v", pod.NamespacedName, resp.StatusCode)
+	}
+
+	var list modelList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode models from %s: %w", pod.NamespacedName, err)
+	}
+	served := &datastore.ServedModels{
+		BaseModels: sets.New[string](),
+		Adapters:   make(map[string]string),
+		UpdateTime: time.Now(),
+	}
+	for _, m := range list.Data {
+		if m.Parent != nil && *m.Parent != "" {
+			served.Adapters[m.ID] = *m.Parent
+		} else {
+			served.BaseModels.Insert(m.ID)
+		}
+	}
+	return served, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openai
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+func TestFetchModels(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		body             string
+		wantErr          bool
+		wantBaseModels   sets.Set[string]
+		wantAdapters     map[string]string
+		wantServesModels []string
+	}{
+		{
+			name:   "base models and adapters",
+			status: http.StatusOK,
+			body: `{"object":"list","data":[
+				{"id":"meta-llama/Llama-2-7b-hf","object":"model","root":"meta-llama/Llama-2-7b-hf","parent":null},
+				{"id":"tweet-summary-1","object":"model","root":"/adapters/tweet-summary-1","parent":"meta-llama/Llama-2-7b-hf"}
+			]}`,
+			wantBaseModels:   sets.New("meta-llama/Llama-2-7b-hf"),
+			wantAdapters:     map[string]string{"tweet-summary-1": "meta-llama/Llama-2-7b-hf"},
+			wantServesModels: []string{"meta-llama/Llama-2-7b-hf", "tweet-summary-1"},
+		},
+		{
+			name:             "no parent field",
+			status:           http.StatusOK,
+			body:             `{"object":"list","data":[{"id":"gpt2","object":"model"}]}`,
+			wantBaseModels:   sets.New("gpt2"),
+			wantAdapters:     map[string]string{},
+			wantServesModels: []string{"gpt2"},
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "invalid body",
+			status:  http.StatusOK,
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != ModelsPath {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer srv.Close()
+
+			client := &ModelsClientImpl{}
+			served, err := client.FetchModels(context.Background(), podFor(t, srv))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, served.Known())
+			assert.Equal(t, test.wantBaseModels, served.BaseModels)
+			assert.Equal(t, test.wantAdapters, served.Adapters)
+			for _, m := range test.wantServesModels {
+				assert.True(t, served.Serves(m), "model %q should be served", m)
+			}
+			assert.False(t, served.Serves("unknown"))
+		})
+	}
+}
+
+func podFor(t *testing.T, srv *httptest.Server) *datastore.PodMetrics {
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &datastore.PodMetrics{Pod: datastore.Pod{Address: host, ScrapePort: int32(port)}}
+}
@@ -52,7 +52,7 @@ type PodMetricsClient interface {
 	FetchMetrics(ctx context.Context, existing *datastore.PodMetrics) (*datastore.PodMetrics, error)
 }
 
-func (p *Provider) Init(ctx context.Context, refreshMetricsInterval, refreshPrometheusMetricsInterval time.Duration) error {
+func (p *Provider) Init(ctx context.Context, refreshMetricsInterval, refreshPrometheusMetricsInterval, metricsStalenessThreshold time.Duration) error {
 	// periodically refresh metrics
 	logger := log.FromContext(ctx)
 	go func() {
@@ -79,7 +79,7 @@ func (p *Provider) Init(ctx context.Context, refreshMetricsInterval, refreshProm
 				return
 			default:
 				time.Sleep(refreshPrometheusMetricsInterval)
-				p.flushPrometheusMetricsOnce(logger)
+				p.flushPrometheusMetricsOnce(logger, metricsStalenessThreshold)
 			}
 		}
 	}()
@@ -94,7 +94,9 @@ func (p *Provider) Init(ctx context.Context, refreshMetricsInterval, refreshProm
 					return
 				default:
 					time.Sleep(5 * time.Second)
-					logger.Info("Current Pods and metrics gathered", "metrics", p.datastore.PodGetAll())
+					podMetrics := p.datastore.PodGetAll()
+					logger.Info("Current Pods and metrics gathered", "metrics", podMetrics,
+						"stalePods", stalePods(podMetrics, metricsStalenessThreshold, time.Now()))
 				}
 			}
 		}()
@@ -123,11 +125,22 @@ func (p *Provider) refreshMetricsOnce(logger logr.Logger) error {
 			defer wg.Done()
 			updated, err := p.pmc.FetchMetrics(ctx, existing)
 			if err != nil {
+				// Keep the last known metrics, but record the failure so that the staleness of
+				// the pod is visible to the scheduler.
+				failed := existing.Metrics
+				failed.ConsecutiveScrapeFailures++
+				p.datastore.PodUpdateMetricsIfExist(existing.NamespacedName, &failed)
+				p.recordScrapeFailure()
 				errCh <- fmt.Errorf("failed to parse metrics from %s: %v", existing.NamespacedName, err)
 				return
 			}
-			p.datastore.PodUpdateMetricsIfExist(updated.NamespacedName, &updated.Metrics)
-			loggerTrace.Info("Updated metrics for pod", "pod", updated.NamespacedName, "metrics", updated.Metrics)
+			// The datastore takes ownership of the metrics, copy them so that the returned pod
+			// metrics are not modified.
+			m := updated.Metrics
+			m.UpdateTime = time.Now()
+			m.ConsecutiveScrapeFailures = 0
+			p.datastore.PodUpdateMetricsIfExist(updated.NamespacedName, &m)
+			loggerTrace.Info("Updated metrics for pod", "pod", updated.NamespacedName, "metrics", m)
 		}()
 		return true
 	}
@@ -150,7 +163,13 @@ func (p *Provider) refreshMetricsOnce(logger logr.Logger) error {
 	return errs
 }
 
-func (p *Provider) flushPrometheusMetricsOnce(logger logr.Logger) {
+func (p *Provider) recordScrapeFailure() {
+	if pool, err := p.datastore.PoolGet(); err == nil {
+		metrics.RecordInferencePoolScrapeFailure(pool.Namespace, pool.Name)
+	}
+}
+
+func (p *Provider) flushPrometheusMetricsOnce(logger logr.Logger, metricsStalenessThreshold time.Duration) {
 	logger.V(logutil.DEBUG).Info("Flushing Prometheus Metrics")
 
 	pool, _ := p.datastore.PoolGet()
@@ -173,6 +192,29 @@ func (p *Provider) flushPrometheusMetricsOnce(logger logr.Logger) {
 	}
 
 	podTotalCount := len(podMetrics)
-	metrics.RecordInferencePoolAvgKVCache(pool.Name, kvCacheTotal/float64(podTotalCount))
-	metrics.RecordInferencePoolAvgQueueSize(pool.Name, float64(queueTotal/podTotalCount))
+	metrics.RecordInferencePoolAvgKVCache(pool.Namespace, pool.Name, kvCacheTotal/float64(podTotalCount))
+	metrics.RecordInferencePoolAvgQueueSize(pool.Namespace, pool.Name, float64(queueTotal/podTotalCount))
+	metrics.RecordInferencePoolStalePods(pool.Namespace, pool.Name, len(stalePods(podMetrics, metricsStalenessThreshold, time.Now())))
+
+	var draining, drainingInFlight int
+	for _, pod := range podMetrics {
+		if pod.Draining {
+			draining++
+			inFlight := p.datastore.PodInFlightRequests(pod.NamespacedName)
+			drainingInFlight += inFlight
+			logger.V(logutil.DEBUG).Info("Pod draining", "name", pod.NamespacedName, "inFlightRequests", inFlight)
+		}
+	}
+	metrics.RecordInferencePoolDrainingPods(pool.Namespace, pool.Name, draining, drainingInFlight)
+}
+
+// stalePods returns the names of the pods whose metrics were not refreshed within the threshold.
+func stalePods(podMetrics []*datastore.PodMetrics, threshold time.Duration, now time.Time) []string {
+	stale := []string{}
+	for _, pm := range podMetrics {
+		if pm.IsStale(threshold, now) {
+			stale = append(stale, pm.NamespacedName.String())
+		}
+	}
+	return stale
 }
@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
 var (
@@ -131,18 +133,89 @@ func TestProvider(t *testing.T) {
 			p := NewProvider(test.pmc, test.datastore)
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
-			_ = p.Init(ctx, time.Millisecond, time.Millisecond)
+			_ = p.Init(ctx, time.Millisecond, time.Millisecond, time.Second)
 			assert.EventuallyWithT(t, func(t *assert.CollectT) {
 				metrics := test.datastore.PodGetAll()
 				diff := cmp.Diff(test.want, metrics, cmpopts.SortSlices(func(a, b *datastore.PodMetrics) bool {
 					return a.String() < b.String()
-				}))
+				}), cmpopts.EquateEmpty(), cmpopts.IgnoreFields(datastore.Metrics{}, "UpdateTime", "ConsecutiveScrapeFailures"))
 				assert.Equal(t, "", diff, "Unexpected diff (+got/-want)")
 			}, 5*time.Second, time.Millisecond)
 		})
 	}
 }
 
+func TestProviderMetricsFreshness(t *testing.T) {
+	pmc := &FakePodMetricsClient{
+		Err: map[types.NamespacedName]error{
+			pod2.NamespacedName: errors.New("injected error"),
+		},
+		Res: map[types.NamespacedName]*datastore.PodMetrics{
+			pod1.NamespacedName: pod1,
+		},
+	}
+	ds := datastore.NewFakeDatastore(populateMap(pod1, pod2), nil, nil)
+	p := NewProvider(pmc, ds)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Now()
+	_ = p.Init(ctx, time.Millisecond, time.Millisecond, time.Second)
+
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		got1, ok := ds.PodGet(pod1.NamespacedName)
+		assert.True(t, ok)
+		assert.False(t, got1.UpdateTime.Before(start), "Successfully scraped pod should have a fresh update time")
+		assert.Equal(t, 0, got1.ConsecutiveScrapeFailures)
+		assert.False(t, got1.IsStale(time.Second, time.Now()))
+
+		got2, ok := ds.PodGet(pod2.NamespacedName)
+		assert.True(t, ok)
+		assert.True(t, got2.UpdateTime.IsZero(), "Pod that failed to be scraped should not have an update time")
+		assert.Greater(t, got2.ConsecutiveScrapeFailures, 1)
+		assert.True(t, got2.IsStale(time.Second, time.Now()))
+	}, 5*time.Second, time.Millisecond)
+}
+
+// TestProviderConcurrentScheduling is meant to be run with the race detector, it schedules requests
+// while metrics and served models are refreshed.
+func TestProviderConcurrentScheduling(t *testing.T) {
+	ds := datastore.NewFakeDatastore(populateMap(pod1, pod2), nil, nil)
+	p := NewProvider(&FakePodMetricsClient{
+		Res: map[types.NamespacedName]*datastore.PodMetrics{
+			pod1.NamespacedName: pod1,
+			pod2.NamespacedName: pod2,
+		},
+	}, ds)
+	mp := NewModelProber(&FakeModelsClient{
+		Res: map[types.NamespacedName]*datastore.ServedModels{
+			pod1.NamespacedName: {Adapters: map[string]string{"foo": "base"}, UpdateTime: time.Now()},
+			pod2.NamespacedName: {Adapters: map[string]string{"foo1": "base"}, UpdateTime: time.Now()},
+		},
+	}, ds)
+	ctx, cancel := context.WithCancel(logutil.NewTestLoggerIntoContext(context.Background()))
+	defer cancel()
+	_ = p.Init(ctx, time.Millisecond, time.Millisecond, time.Second)
+	_ = mp.Init(ctx, time.Millisecond)
+
+	scheduler := scheduling.NewScheduler(ds)
+	req := &scheduling.LLMRequest{Model: "foo", ResolvedTargetModel: "foo", Critical: true}
+	deadline := time.Now().Add(200 * time.Millisecond)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				if _, err := scheduler.Schedule(ctx, req); err != nil {
+					t.Errorf("Unexpected scheduling error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func populateMap(pods ...*datastore.PodMetrics) *sync.Map {
 	newMap := &sync.Map{}
 	for _, pod := range pods {
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// scrapeIdleConnTimeout keeps connections to the model servers alive between scrapes.
+	scrapeIdleConnTimeout = 90 * time.Second
+)
+
+// ScrapeTransportConfig configures the HTTP transport used to scrape model server metrics.
+type ScrapeTransportConfig struct {
+	// CAFile is the path to a PEM encoded CA bundle used to verify the model server certificates.
+	// If empty, the system roots are used. The file is re-read when it changes.
+	CAFile string
+	// CertFile and KeyFile are the paths to the PEM encoded client certificate and key presented
+	// to the model servers. Both or neither must be set. The files are re-read when they change.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables the verification of the model server certificates.
+	InsecureSkipVerify bool
+	// BearerTokenFile is the path to a file containing a bearer token sent with every scrape.
+	// The file is re-read when it changes, so rotated tokens are picked up.
+	BearerTokenFile string
+}
+
+// Validate checks that the configuration is consistent.
+func (c ScrapeTransportConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("both the client certificate and key files must be set for scraping metrics with mTLS")
+	}
+	return nil
+}
+
+// NewScrapeHTTPClient returns an HTTP client for scraping model server metrics. The client reuses
+// keep-alive connections across scrapes as long as the response bodies are drained and closed.
+func NewScrapeHTTPClient(config ScrapeTransportConfig) (*http.Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	var ca *caFile
+	if config.CAFile != "" {
+		ca = &caFile{path: config.CAFile}
+		// Fail fast on an invalid CA bundle rather than on the first scrape.
+		if _, err := ca.get(); err != nil {
+			return nil, err
+		}
+	}
+	if config.CertFile != "" {
+		kp := &keyPairFile{certFile: config.CertFile, keyFile: config.KeyFile}
+		// Fail fast on an invalid key pair rather than on the first scrape.
+		if _, err := kp.get(); err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return kp.get()
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.IdleConnTimeout = scrapeIdleConnTimeout
+	if ca != nil {
+		// The roots of a tls.Config cannot be swapped, so every new connection is dialed with the
+		// current CA bundle.
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return ca.dialTLS(ctx, dialer, tlsConfig, network, addr)
+		}
+	}
+
+	var rt http.RoundTripper = transport
+	if config.BearerTokenFile != "" {
+		tf := &tokenFile{path: config.BearerTokenFile}
+		if _, err := tf.get(); err != nil {
+			return nil, err
+		}
+		rt = &bearerTokenRoundTripper{token: tf, next: transport}
+	}
+	return &http.Client{Transport: rt}, nil
+}
+
+// bearerTokenRoundTripper sets the Authorization header from a token file on every request.
+type bearerTokenRoundTripper struct {
+	token *tokenFile
+	next  http.RoundTripper
+}
+
+func (rt *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.token.get()
+	if err != nil {
+		return nil, err
+	}
+	// RoundTrippers must not modify the original request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return rt.next.RoundTrip(req)
+}
+
+// tokenFile caches the content of a token file, re-reading it when its modification time changes.
+type tokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func (f *tokenFile) get() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat bearer token file %q: %w", f.path, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && info.ModTime().Equal(f.modTime) {
+		return f.token, nil
+	}
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read bearer token file %q: %w", f.path, err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("bearer token file %q is empty", f.path)
+	}
+	f.token = token
+	f.modTime = info.ModTime()
+	return f.token, nil
+}
+
+// caFile caches a CA bundle, re-reading it when its modification time changes.
+type caFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	pool    *x509.CertPool
+}
+
+func (f *caFile) get() (*x509.CertPool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat CA file %q: %w", f.path, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pool != nil && info.ModTime().Equal(f.modTime) {
+		return f.pool, nil
+	}
+	caPEM, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file %q: %w", f.path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in CA file %q", f.path)
+	}
+	f.pool = pool
+	f.modTime = info.ModTime()
+	return f.pool, nil
+}
+
+// dialTLS dials addr with a copy of config that trusts the current CA bundle. The server name
+// defaults to the host of addr, as it does for the connections dialed by http.Transport.
+func (f *caFile) dialTLS(ctx context.Context, dialer *net.Dialer, config *tls.Config, network, addr string) (net.Conn, error) {
+	pool, err := f.get()
+	if err != nil {
+		return nil, err
+	}
+	config = config.Clone()
+	config.RootCAs = pool
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		config.ServerName = host
+	}
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: config}
+	return tlsDialer.DialContext(ctx, network, addr)
+}
+
+// keyPairFile caches a client certificate, re-loading it when either file's modification time
+// changes.
+type keyPairFile struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.Mutex
+	certModTime time.Time
+	keyModTime  time.Time
+	cert        *tls.Certificate
+}
+
+func (f *keyPairFile) get() (*tls.Certificate, error) {
+	certInfo, err := os.Stat(f.certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat client certificate file %q: %w", f.certFile, err)
+	}
+	keyInfo, err := os.Stat(f.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat client key file %q: %w", f.keyFile, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cert != nil && certInfo.ModTime().Equal(f.certModTime) && keyInfo.ModTime().Equal(f.keyModTime) {
+		return f.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client key pair: %w", err)
+	}
+	f.cert = &cert
+	f.certModTime = certInfo.ModTime()
+	f.keyModTime = keyInfo.ModTime()
+	return f.cert, nil
+}
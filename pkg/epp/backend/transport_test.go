@@ -0,0 +1,355 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScrapeHTTPClientTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	caFile := writeCertPEM(t, srv.Certificate())
+
+	tests := []struct {
+		name    string
+		config  ScrapeTransportConfig
+		wantErr bool
+	}{
+		{
+			name:    "unknown authority",
+			config:  ScrapeTransportConfig{},
+			wantErr: true,
+		},
+		{
+			name:   "CA bundle",
+			config: ScrapeTransportConfig{CAFile: caFile},
+		},
+		{
+			name:   "insecure skip verify",
+			config: ScrapeTransportConfig{InsecureSkipVerify: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := NewScrapeHTTPClient(test.config)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			_, err = get(client, srv.URL)
+			if test.wantErr != (err != nil) {
+				t.Errorf("Unexpected error, got %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestScrapeHTTPClientMTLS(t *testing.T) {
+	ca, caKey := newCA(t)
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(ca)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientPool,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+	caFile := writeCertPEM(t, srv.Certificate())
+
+	// A client without a certificate is rejected.
+	client, err := NewScrapeHTTPClient(ScrapeTransportConfig{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := get(client, srv.URL); err == nil {
+		t.Error("Expected the scrape without a client certificate to fail")
+	}
+
+	// A client with a certificate signed by the trusted CA is accepted.
+	certFile, keyFile := writeClientKeyPair(t, ca, caKey, "epp")
+	client, err = NewScrapeHTTPClient(ScrapeTransportConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	body, err := get(client, srv.URL)
+	if err != nil {
+		t.Fatalf("Unexpected scrape error: %v", err)
+	}
+	if body != "epp" {
+		t.Errorf("Unexpected client certificate common name: %q", body)
+	}
+
+	// The rotated client certificate is presented on new connections.
+	newCertFile, newKeyFile := writeClientKeyPair(t, ca, caKey, "epp-rotated")
+	replaceFile(t, newCertFile, certFile)
+	replaceFile(t, newKeyFile, keyFile)
+	client.CloseIdleConnections()
+	body, err = get(client, srv.URL)
+	if err != nil {
+		t.Fatalf("Unexpected scrape error: %v", err)
+	}
+	if body != "epp-rotated" {
+		t.Errorf("Unexpected client certificate common name after rotation: %q", body)
+	}
+
+	// Incomplete key pairs are rejected.
+	if _, err := NewScrapeHTTPClient(ScrapeTransportConfig{CertFile: certFile}); err == nil {
+		t.Error("Expected an error for a client certificate without a key")
+	}
+}
+
+func TestScrapeHTTPClientCARotation(t *testing.T) {
+	oldCA, oldCAKey := newCA(t)
+	newCA, newCAKey := newCA(t)
+	var serverCert atomic.Pointer[tls.Certificate]
+	serverCert.Store(newServerCert(t, oldCA, oldCAKey))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	// The test server certificate set by StartTLS takes precedence over GetCertificate for clients
+	// that connect by IP, so the whole config is swapped instead.
+	srv.TLS = &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{Certificates: []tls.Certificate{*serverCert.Load()}}, nil
+		},
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := writeCertPEM(t, oldCA)
+	client, err := NewScrapeHTTPClient(ScrapeTransportConfig{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := get(client, srv.URL); err != nil {
+		t.Fatalf("Unexpected scrape error: %v", err)
+	}
+
+	// A server certificate signed by the new CA is rejected until the CA bundle is rotated.
+	serverCert.Store(newServerCert(t, newCA, newCAKey))
+	client.CloseIdleConnections()
+	if _, err := get(client, srv.URL); err == nil {
+		t.Error("Expected the scrape to fail before the CA rotation")
+	}
+
+	replaceFile(t, writeCertPEM(t, newCA), caFile)
+	client.CloseIdleConnections()
+	if _, err := get(client, srv.URL); err != nil {
+		t.Errorf("Unexpected scrape error after the CA rotation: %v", err)
+	}
+}
+
+func TestScrapeHTTPClientBearerTokenRotation(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("token-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewScrapeHTTPClient(ScrapeTransportConfig{
+		CAFile:          writeCertPEM(t, srv.Certificate()),
+		BearerTokenFile: tokenFile,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if body, err := get(client, srv.URL); err != nil || body != "Bearer token-1" {
+		t.Errorf("Unexpected response %q, error: %v", body, err)
+	}
+
+	if err := os.WriteFile(tokenFile, []byte("token-2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the modification time changes even on file systems with a coarse resolution.
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(tokenFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if body, err := get(client, srv.URL); err != nil || body != "Bearer token-2" {
+		t.Errorf("Unexpected response after token rotation %q, error: %v", body, err)
+	}
+
+	if _, err := NewScrapeHTTPClient(ScrapeTransportConfig{BearerTokenFile: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected an error for a missing token file")
+	}
+}
+
+func TestScrapeHTTPClientKeepAlive(t *testing.T) {
+	var newConns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("vllm:num_requests_waiting 0\n"))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	client, err := NewScrapeHTTPClient(ScrapeTransportConfig{CAFile: writeCertPEM(t, srv.Certificate())})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	for range 10 {
+		if _, err := get(client, srv.URL); err != nil {
+			t.Fatalf("Unexpected scrape error: %v", err)
+		}
+	}
+	if got := newConns.Load(); got != 1 {
+		t.Errorf("Expected a single connection to be reused across scrapes, got %d connections", got)
+	}
+}
+
+func get(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func newCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func writeClientKeyPair(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func newServerCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) *tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "model-server"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func writeCertPEM(t *testing.T, cert *x509.Certificate) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// replaceFile atomically replaces dst with src and bumps its modification time.
+func replaceFile(t *testing.T, src, dst string) {
+	t.Helper()
+	if err := os.Rename(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(dst, future, future); err != nil {
+		t.Fatal(err)
+	}
+}
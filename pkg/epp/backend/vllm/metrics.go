@@ -20,6 +20,7 @@ package vllm
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -47,9 +48,24 @@ const (
 	*/
 	KVCacheUsagePercentMetricName     = "vllm:gpu_cache_usage_perc"
 	KvCacheMaxTokenCapacityMetricName = "vllm:gpu_cache_max_token_capacity"
+	// CacheConfigInfoMetricName is used to estimate the KV cache token capacity as
+	// num_gpu_blocks * block_size when KvCacheMaxTokenCapacityMetricName is not exposed.
+	CacheConfigInfoMetricName        = "vllm:cache_config_info"
+	CacheConfigInfoNumGPUBlocksLabel = "num_gpu_blocks"
+	CacheConfigInfoBlockSizeLabel    = "block_size"
 )
 
-type PodMetricsClientImpl struct{}
+type PodMetricsClientImpl struct {
+	// Client is used to scrape the model servers. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// Scheme is the URL scheme used to scrape pods that do not override it. Defaults to "http".
+	Scheme string
+	// RateWindow is the window over which rates and latency quantiles are derived from counters
+	// and histograms. Defaults to DefaultRateWindow.
+	RateWindow time.Duration
+
+	rates rateTracker
+}
 
 // FetchMetrics fetches metrics from a given pod.
 func (p *PodMetricsClientImpl) FetchMetrics(
@@ -61,18 +77,24 @@ func (p *PodMetricsClientImpl) FetchMetrics(
 
 	// Currently the metrics endpoint is hard-coded, which works with vLLM.
 	// TODO(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/16): Consume this from InferencePool config.
-	url := existing.BuildScrapeEndpoint()
+	url := existing.BuildScrapeEndpoint(p.Scheme)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		loggerDefault.Error(err, "Failed create HTTP request", "method", http.MethodGet, "url", url)
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		loggerDefault.Error(err, "Failed to fetch metrics", "pod", existing.NamespacedName)
 		return nil, fmt.Errorf("failed to fetch metrics from %s: %w", existing.NamespacedName, err)
 	}
 	defer func() {
+		// Drain the body so that the keep-alive connection can be reused by the next scrape.
+		_, _ = io.Copy(io.Discard, resp.Body)
 		_ = resp.Body.Close()
 	}()
 
@@ -86,7 +108,12 @@ func (p *PodMetricsClientImpl) FetchMetrics(
 	if err != nil {
 		return nil, err
 	}
-	return promToPodMetrics(logger, metricFamilies, existing)
+	updated, err := promToPodMetrics(logger, metricFamilies, existing)
+	if err != nil {
+		return updated, err
+	}
+	p.rates.update(logger, p.RateWindow, time.Now(), metricFamilies, updated)
+	return updated, nil
 }
 
 // promToPodMetrics updates internal pod metrics with scraped prometheus metrics.
@@ -117,13 +144,11 @@ func promToPodMetrics(
 
 	loraMetrics, _, err := getLatestLoraMetric(logger, metricFamilies)
 	errs = multierr.Append(errs, err)
-	/* TODO: uncomment once this is available in vllm.
-	kvCap, _, err := getGaugeLatestValue(metricFamilies, KvCacheMaxTokenCapacityMetricName)
-	errs = multierr.Append(errs, err)
-	if err != nil {
-		updated.KvCacheMaxTokenCapacity = int(kvCap)
+	// The KV cache token capacity is optional, the capacity from the previous scrape is kept if it
+	// cannot be determined.
+	if kvCap, ok := getKvCacheMaxTokenCapacity(logger, metricFamilies); ok {
+		updated.KvCacheMaxTokenCapacity = kvCap
 	}
-	*/
 
 	if loraMetrics != nil {
 		updated.ActiveModels = make(map[string]int)
@@ -151,6 +176,39 @@ func promToPodMetrics(
 	return updated, errs
 }
 
+// getKvCacheMaxTokenCapacity returns the number of tokens that fit into the KV cache. It is read
+// from KvCacheMaxTokenCapacityMetricName if exposed, and estimated from the cache config otherwise.
+func getKvCacheMaxTokenCapacity(logger logr.Logger, metricFamilies map[string]*dto.MetricFamily) (int, bool) {
+	if mf, ok := metricFamilies[KvCacheMaxTokenCapacityMetricName]; ok && len(mf.GetMetric()) > 0 {
+		kvCap, err := getLatestMetric(logger, metricFamilies, KvCacheMaxTokenCapacityMetricName)
+		if err == nil && kvCap.GetGauge().GetValue() > 0 {
+			return int(kvCap.GetGauge().GetValue()), true
+		}
+	}
+	mf, ok := metricFamilies[CacheConfigInfoMetricName]
+	if !ok || len(mf.GetMetric()) == 0 {
+		return 0, false
+	}
+	var numBlocks, blockSize int
+	for _, label := range mf.GetMetric()[0].GetLabel() {
+		var err error
+		switch label.GetName() {
+		case CacheConfigInfoNumGPUBlocksLabel:
+			numBlocks, err = strconv.Atoi(label.GetValue())
+		case CacheConfigInfoBlockSizeLabel:
+			blockSize, err = strconv.Atoi(label.GetValue())
+		}
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Invalid cache config label", "label", label.GetName(), "value", label.GetValue())
+			return 0, false
+		}
+	}
+	if numBlocks <= 0 || blockSize <= 0 {
+		return 0, false
+	}
+	return numBlocks * blockSize, true
+}
+
 // getLatestLoraMetric gets latest lora metric series in gauge metric family `vllm:lora_requests_info`
 // reason its specially fetched is because each label key value pair permutation generates new series
 // and only most recent is useful. The value of each series is the creation timestamp so we can
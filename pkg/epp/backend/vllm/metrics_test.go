@@ -17,7 +17,12 @@ limitations under the License.
 package vllm
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	dto "github.com/prometheus/client_model/go"
@@ -248,3 +253,133 @@ func TestPromToPodMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchMetricsScheme(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running 3
+# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting 7
+# TYPE vllm:gpu_cache_usage_perc gauge
+vllm:gpu_cache_usage_perc 0.5
+# TYPE vllm:lora_requests_info gauge
+vllm:lora_requests_info{max_lora="2",running_lora_adapters="lora1"} 1
+`))
+	}))
+	defer srv.Close()
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &datastore.PodMetrics{
+		Pod: datastore.Pod{Address: host, ScrapePort: int32(port), ScrapePath: "/metrics"},
+	}
+
+	tests := []struct {
+		name         string
+		clientScheme string
+		podScheme    string
+		wantErr      bool
+	}{
+		{
+			name:    "plain http against a TLS server",
+			wantErr: true,
+		},
+		{
+			name:         "https from the client default",
+			clientScheme: "https",
+		},
+		{
+			name:         "https from the pod override",
+			clientScheme: "http",
+			podScheme:    "https",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := pod.Clone()
+			existing.ScrapeScheme = test.podScheme
+			client := &PodMetricsClientImpl{Client: srv.Client(), Scheme: test.clientScheme}
+			updated, err := client.FetchMetrics(context.Background(), existing)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 3, updated.RunningQueueSize)
+			assert.Equal(t, 7, updated.WaitingQueueSize)
+			assert.Equal(t, 0.5, updated.KVCacheUsagePercent)
+			assert.Equal(t, map[string]int{"lora1": 0}, updated.ActiveModels)
+		})
+	}
+}
+
+func TestGetKvCacheMaxTokenCapacity(t *testing.T) {
+	logger := logutil.NewTestLogger()
+	cacheConfigInfo := func(numBlocks, blockSize string) *dto.MetricFamily {
+		return &dto.MetricFamily{
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("cache_dtype"), Value: proto.String("auto")},
+						{Name: proto.String(CacheConfigInfoNumGPUBlocksLabel), Value: proto.String(numBlocks)},
+						{Name: proto.String(CacheConfigInfoBlockSizeLabel), Value: proto.String(blockSize)},
+					},
+					Gauge: &dto.Gauge{Value: proto.Float64(1)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		metricFamilies map[string]*dto.MetricFamily
+		wantCapacity   int
+		wantOk         bool
+	}{
+		{
+			name: "capacity metric",
+			metricFamilies: map[string]*dto.MetricFamily{
+				KvCacheMaxTokenCapacityMetricName: {
+					Metric: []*dto.Metric{{Gauge: &dto.Gauge{Value: proto.Float64(4096)}}},
+				},
+				CacheConfigInfoMetricName: cacheConfigInfo("100", "16"),
+			},
+			wantCapacity: 4096,
+			wantOk:       true,
+		},
+		{
+			name: "estimated from cache config",
+			metricFamilies: map[string]*dto.MetricFamily{
+				CacheConfigInfoMetricName: cacheConfigInfo("100", "16"),
+			},
+			wantCapacity: 1600,
+			wantOk:       true,
+		},
+		{
+			name: "invalid cache config",
+			metricFamilies: map[string]*dto.MetricFamily{
+				CacheConfigInfoMetricName: cacheConfigInfo("None", "16"),
+			},
+		},
+		{
+			name:           "not available",
+			metricFamilies: map[string]*dto.MetricFamily{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capacity, ok := getKvCacheMaxTokenCapacity(logger, test.metricFamilies)
+			assert.Equal(t, test.wantOk, ok)
+			assert.Equal(t, test.wantCapacity, capacity)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vllm
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	PromptTokensMetricName      = "vllm:prompt_tokens_total"
+	GenerationTokensMetricName  = "vllm:generation_tokens_total"
+	TimeToFirstTokenMetricName  = "vllm:time_to_first_token_seconds"
+	E2ERequestLatencyMetricName = "vllm:e2e_request_latency_seconds"
+
+	// DefaultRateWindow is the default window over which rates and quantiles are derived.
+	DefaultRateWindow = 10 * time.Second
+	// latencyQuantile is the quantile of the latency histograms exported as derived metrics.
+	latencyQuantile = 0.9
+	// samplesPerWindow bounds the number of samples kept per pod.
+	samplesPerWindow = 10
+	// staleHistoryWindows is the number of windows after which the history of a pod that is no
+	// longer scraped, e.g. because it was deleted, is dropped.
+	staleHistoryWindows = 10
+)
+
+var (
+	rateCounters   = []string{PromptTokensMetricName, GenerationTokensMetricName}
+	rateHistograms = []string{TimeToFirstTokenMetricName, E2ERequestLatencyMetricName}
+)
+
+// sample is the subset of a single scrape needed to derive rates and quantiles.
+type sample struct {
+	time       time.Time
+	counters   map[string]float64
+	histograms map[string][]bucket
+}
+
+// bucket is a cumulative histogram bucket.
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// rateTracker keeps a short history of scrapes per pod to derive windowed rates from counters
+// and quantiles from histograms. The zero value is ready to use.
+type rateTracker struct {
+	mu        sync.Mutex
+	histories map[types.NamespacedName][]*sample
+	lastPrune time.Time
+}
+
+// update records the given scrape and sets the derived metrics of the pod. Derived metrics are
+// zero until two scrapes are available, and after a counter reset, e.g. a model server restart.
+// Metric families missing from the scrape are ignored, since not all model servers export them.
+func (t *rateTracker) update(
+	logger logr.Logger,
+	window time.Duration,
+	now time.Time,
+	metricFamilies map[string]*dto.MetricFamily,
+	updated *datastore.PodMetrics,
+) {
+	if window <= 0 {
+		window = DefaultRateWindow
+	}
+	current := newSample(now, metricFamilies)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.histories == nil {
+		t.histories = make(map[types.NamespacedName][]*sample)
+	}
+	t.pruneLocked(window, now)
+
+	history := t.histories[updated.NamespacedName]
+	base := baseSample(history, current, window)
+	updated.PromptTokensPerSecond = 0
+	updated.GenerationTokensPerSecond = 0
+	updated.TimeToFirstTokenP90Seconds = 0
+	updated.E2ERequestLatencyP90Seconds = 0
+	switch {
+	case base == nil:
+		history = []*sample{current}
+	case counterReset(base, current):
+		logger.V(logutil.DEBUG).Info("Counter reset detected, restarting rate window", "pod", updated.NamespacedName)
+		history = []*sample{current}
+	default:
+		elapsed := current.time.Sub(base.time).Seconds()
+		updated.PromptTokensPerSecond = rate(base, current, PromptTokensMetricName, elapsed)
+		updated.GenerationTokensPerSecond = rate(base, current, GenerationTokensMetricName, elapsed)
+		updated.TimeToFirstTokenP90Seconds = quantile(base, current, TimeToFirstTokenMetricName, latencyQuantile)
+		updated.E2ERequestLatencyP90Seconds = quantile(base, current, E2ERequestLatencyMetricName, latencyQuantile)
+		history = appendSample(history, current, window)
+	}
+	t.histories[updated.NamespacedName] = history
+}
+
+// pruneLocked drops the histories of pods that were not scraped recently.
+func (t *rateTracker) pruneLocked(window time.Duration, now time.Time) {
+	maxAge := staleHistoryWindows * window
+	if now.Sub(t.lastPrune) < maxAge {
+		return
+	}
+	t.lastPrune = now
+	for name, history := range t.histories {
+		if len(history) == 0 || now.Sub(history[len(history)-1].time) > maxAge {
+			delete(t.histories, name)
+		}
+	}
+}
+
+// baseSample returns the sample the current one should be compared with: the most recent sample
+// that is at least one window old, or the oldest sample if the history is shorter than a window.
+func baseSample(history []*sample, current *sample, window time.Duration) *sample {
+	var base *sample
+	for _, s := range history {
+		if !s.time.Before(current.time) {
+			continue
+		}
+		if base == nil || current.time.Sub(s.time) >= window {
+			base = s
+		}
+	}
+	return base
+}
+
+// appendSample adds the current sample to the history if it is sufficiently far apart from the
+// last one, and drops the samples that are no longer needed to cover the window.
+func appendSample(history []*sample, current *sample, window time.Duration) []*sample {
+	if n := len(history); n == 0 || current.time.Sub(history[n-1].time) >= window/samplesPerWindow {
+		history = append(history, current)
+	}
+	for len(history) > 1 && current.time.Sub(history[1].time) >= window {
+		history = history[1:]
+	}
+	return history
+}
+
+func newSample(now time.Time, metricFamilies map[string]*dto.MetricFamily) *sample {
+	s := &sample{
+		time:       now,
+		counters:   make(map[string]float64, len(rateCounters)),
+		histograms: make(map[string][]bucket, len(rateHistograms)),
+	}
+	for _, name := range rateCounters {
+		if mf, ok := metricFamilies[name]; ok {
+			s.counters[name] = sumCounter(mf)
+		}
+	}
+	for _, name := range rateHistograms {
+		if mf, ok := metricFamilies[name]; ok {
+			s.histograms[name] = mergeHistogram(mf)
+		}
+	}
+	return s
+}
+
+// sumCounter sums all series of a counter family, e.g. across models.
+func sumCounter(mf *dto.MetricFamily) float64 {
+	var sum float64
+	for _, m := range mf.GetMetric() {
+		sum += m.GetCounter().GetValue()
+	}
+	return sum
+}
+
+// mergeHistogram merges all series of a histogram family into a single set of cumulative buckets
+// sorted by upper bound, ending with the +Inf bucket.
+func mergeHistogram(mf *dto.MetricFamily) []bucket {
+	counts := make(map[float64]float64)
+	for _, m := range mf.GetMetric() {
+		h := m.GetHistogram()
+		hasInf := false
+		for _, b := range h.GetBucket() {
+			counts[b.GetUpperBound()] += float64(b.GetCumulativeCount())
+			hasInf = hasInf || math.IsInf(b.GetUpperBound(), 1)
+		}
+		if !hasInf {
+			counts[math.Inf(1)] += float64(h.GetSampleCount())
+		}
+	}
+	buckets := make([]bucket, 0, len(counts))
+	for ub, count := range counts {
+		buckets = append(buckets, bucket{upperBound: ub, count: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].upperBound < buckets[j].upperBound })
+	return buckets
+}
+
+func counterReset(base, current *sample) bool {
+	for name, value := range current.counters {
+		if prev, ok := base.counters[name]; ok && value < prev {
+			return true
+		}
+	}
+	for name, buckets := range current.histograms {
+		prev, ok := base.histograms[name]
+		if ok && len(prev) > 0 && len(buckets) > 0 && buckets[len(buckets)-1].count < prev[len(prev)-1].count {
+			return true
+		}
+	}
+	return false
+}
+
+func rate(base, current *sample, name string, elapsedSeconds float64) float64 {
+	prev, ok := base.counters[name]
+	value, ok2 := current.counters[name]
+	if !ok || !ok2 || elapsedSeconds <= 0 {
+		return 0
+	}
+	return (value - prev) / elapsedSeconds
+}
+
+// quantile estimates the quantile of the observations made between the base and the current
+// sample. It returns 0 if there were no observations.
+func quantile(base, current *sample, name string, q float64) float64 {
+	buckets, ok := current.histograms[name]
+	if !ok {
+		return 0
+	}
+	prevCounts := make(map[float64]float64)
+	for _, b := range base.histograms[name] {
+		prevCounts[b.upperBound] = b.count
+	}
+	delta := make([]bucket, 0, len(buckets))
+	for _, b := range buckets {
+		delta = append(delta, bucket{upperBound: b.upperBound, count: b.count - prevCounts[b.upperBound]})
+	}
+	return bucketQuantile(q, delta)
+}
+
+// bucketQuantile estimates a quantile from cumulative buckets sorted by upper bound, assuming a
+// linear distribution within each bucket, like the PromQL histogram_quantile function.
+func bucketQuantile(q float64, buckets []bucket) float64 {
+	if len(buckets) == 0 {
+		return 0
+	}
+	total := buckets[len(buckets)-1].count
+	if total <= 0 {
+		return 0
+	}
+	rank := q * total
+	i := sort.Search(len(buckets), func(i int) bool { return buckets[i].count >= rank })
+	if i >= len(buckets) {
+		i = len(buckets) - 1
+	}
+	if math.IsInf(buckets[i].upperBound, 1) {
+		// The quantile falls into the +Inf bucket, return the highest finite upper bound.
+		if i == 0 {
+			return 0
+		}
+		return buckets[i-1].upperBound
+	}
+	lower, lowerCount := 0.0, 0.0
+	if i > 0 {
+		lower, lowerCount = buckets[i-1].upperBound, buckets[i-1].count
+	}
+	upper, count := buckets[i].upperBound, buckets[i].count-lowerCount
+	if count <= 0 {
+		return upper
+	}
+	return lower + (upper-lower)*(rank-lowerCount)/count
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vllm
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestBucketQuantile(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       float64
+		buckets []bucket
+		want    float64
+	}{
+		{
+			name: "no observations",
+			q:    0.9,
+			buckets: []bucket{
+				{upperBound: 1, count: 0},
+				{upperBound: math.Inf(1), count: 0},
+			},
+			want: 0,
+		},
+		{
+			name: "interpolated within the first bucket",
+			q:    0.5,
+			buckets: []bucket{
+				{upperBound: 1, count: 10},
+				{upperBound: 2, count: 10},
+				{upperBound: math.Inf(1), count: 10},
+			},
+			want: 0.5,
+		},
+		{
+			name: "interpolated within a middle bucket",
+			q:    0.9,
+			buckets: []bucket{
+				{upperBound: 0.1, count: 50},
+				{upperBound: 0.5, count: 80},
+				{upperBound: 1, count: 100},
+				{upperBound: math.Inf(1), count: 100},
+			},
+			want: 0.75,
+		},
+		{
+			name: "falls into the +Inf bucket",
+			q:    0.9,
+			buckets: []bucket{
+				{upperBound: 1, count: 1},
+				{upperBound: math.Inf(1), count: 10},
+			},
+			want: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.want, bucketQuantile(test.q, test.buckets), 1e-9)
+		})
+	}
+}
+
+func TestRateTracker(t *testing.T) {
+	logger := logutil.NewTestLogger()
+	window := 10 * time.Second
+	start := time.Now()
+	tracker := &rateTracker{}
+	pod := types.NamespacedName{Name: "pod1"}
+
+	scrape := func(offset time.Duration, promptTokens, generationTokens float64, ttftBuckets map[float64]uint64) *datastore.PodMetrics {
+		updated := &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: pod}}
+		families := map[string]*dto.MetricFamily{
+			PromptTokensMetricName:     counterFamily(PromptTokensMetricName, promptTokens),
+			GenerationTokensMetricName: counterFamily(GenerationTokensMetricName, generationTokens),
+		}
+		if ttftBuckets != nil {
+			families[TimeToFirstTokenMetricName] = histogramFamily(TimeToFirstTokenMetricName, ttftBuckets)
+		}
+		tracker.update(logger, window, start.Add(offset), families, updated)
+		return updated
+	}
+
+	// The first scrape has nothing to compare with.
+	got := scrape(0, 100, 1000, map[float64]uint64{0.1: 10, 1: 10})
+	assert.Zero(t, got.PromptTokensPerSecond)
+	assert.Zero(t, got.GenerationTokensPerSecond)
+	assert.Zero(t, got.TimeToFirstTokenP90Seconds)
+
+	// Rates and quantiles are derived from the observations since the first scrape.
+	got = scrape(5*time.Second, 600, 2000, map[float64]uint64{0.1: 10, 1: 20})
+	assert.InDelta(t, 100, got.PromptTokensPerSecond, 1e-9)
+	assert.InDelta(t, 200, got.GenerationTokensPerSecond, 1e-9)
+	// All 10 new observations are in the (0.1, 1] bucket.
+	assert.InDelta(t, 0.91, got.TimeToFirstTokenP90Seconds, 1e-9)
+	// The model server does not export the e2e latency histogram.
+	assert.Zero(t, got.E2ERequestLatencyP90Seconds)
+
+	// Once the window is exceeded, the base sample moves forward.
+	got = scrape(15*time.Second, 1100, 2000, map[float64]uint64{0.1: 20, 1: 30})
+	assert.InDelta(t, 50, got.PromptTokensPerSecond, 1e-9)
+	assert.InDelta(t, 0, got.GenerationTokensPerSecond, 1e-9)
+	assert.InDelta(t, 0.09, got.TimeToFirstTokenP90Seconds, 1e-9)
+
+	// A counter reset, e.g. after a model server restart, restarts the window.
+	got = scrape(20*time.Second, 10, 10, map[float64]uint64{0.1: 0, 1: 0})
+	assert.Zero(t, got.PromptTokensPerSecond)
+	assert.Zero(t, got.GenerationTokensPerSecond)
+	got = scrape(22*time.Second, 210, 10, map[float64]uint64{0.1: 0, 1: 0})
+	assert.InDelta(t, 100, got.PromptTokensPerSecond, 1e-9)
+	assert.Zero(t, got.TimeToFirstTokenP90Seconds)
+
+	// Histories of pods that are no longer scraped are dropped eventually.
+	tracker.update(logger, window, start.Add(time.Hour), map[string]*dto.MetricFamily{},
+		&datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}}})
+	assert.NotContains(t, tracker.histories, pod)
+}
+
+func TestMergeHistogram(t *testing.T) {
+	mf := &dto.MetricFamily{
+		Name: proto.String(TimeToFirstTokenMetricName),
+		Type: dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{
+			{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(3),
+				Bucket: []*dto.Bucket{
+					{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(1)},
+					{UpperBound: proto.Float64(0.1), CumulativeCount: proto.Uint64(1)},
+				},
+			}},
+			{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(2),
+				Bucket: []*dto.Bucket{
+					{UpperBound: proto.Float64(0.1), CumulativeCount: proto.Uint64(1)},
+					{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+					{UpperBound: proto.Float64(math.Inf(1)), CumulativeCount: proto.Uint64(2)},
+				},
+			}},
+		},
+	}
+	want := []bucket{
+		{upperBound: 0.1, count: 2},
+		{upperBound: 1, count: 3},
+		{upperBound: math.Inf(1), count: 5},
+	}
+	assert.Equal(t, want, mergeHistogram(mf))
+}
+
+func counterFamily(name string, value float64) *dto.MetricFamily {
+	return &dto.MetricFamily{
+		Name: proto.String(name),
+		Type: dto.MetricType_COUNTER.Enum(),
+		Metric: []*dto.Metric{
+			{Counter: &dto.Counter{Value: proto.Float64(value)}},
+		},
+	}
+}
+
+func histogramFamily(name string, cumulativeCounts map[float64]uint64) *dto.MetricFamily {
+	h := &dto.Histogram{}
+	var total uint64
+	for ub, count := range cumulativeCounts {
+		h.Bucket = append(h.Bucket, &dto.Bucket{UpperBound: proto.Float64(ub), CumulativeCount: proto.Uint64(count)})
+		total = max(total, count)
+	}
+	h.SampleCount = proto.Uint64(total)
+	return &dto.MetricFamily{
+		Name:   proto.String(name),
+		Type:   dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{{Histogram: h}},
+	}
+}
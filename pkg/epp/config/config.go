@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config defines the versioned config file of the EPP. The file sets the defaults of the
+// settings that are not set by command line flags, flags that are set explicitly take precedence.
+// The scheduling settings are reloaded when the file changes, see Watcher, other settings only
+// take effect after a restart.
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/vllm"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion is the only supported apiVersion of the config file.
+	APIVersion = "config.inference.networking.x-k8s.io/v1alpha1"
+	// Kind is the kind of the config file.
+	Kind = "EndpointPickerConfig"
+)
+
+// EndpointPickerConfig is the content of the config file, e.g.:
+//
+//	apiVersion: config.inference.networking.x-k8s.io/v1alpha1
+//	kind: EndpointPickerConfig
+//	scheduling:
+//	  kvCacheThreshold: 0.9
+//	  picker: LeastInFlightRequests
+//	metrics:
+//	  stalenessThreshold: 5s
+//	  scrapeScheme: https
+//	poolStatus:
+//	  minReadyPods: 2
+//
+// Unset settings are defaulted to the defaults of the corresponding flags.
+type EndpointPickerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Scheduling configures the scheduler. Changes are applied at runtime.
+	Scheduling SchedulingConfig `json:"scheduling,omitempty"`
+	// Metrics configures the model server metrics. Changes require a restart.
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+	// PoolStatus configures the Ready condition of the InferencePools. Changes require a restart.
+	PoolStatus PoolStatusConfig `json:"poolStatus,omitempty"`
+}
+
+// SchedulingConfig configures the scheduler, see scheduling.Config.
+type SchedulingConfig struct {
+	// StalenessPolicy defines how pods with stale metrics are scheduled, see --stalenessPolicy.
+	StalenessPolicy scheduling.StalenessPolicy `json:"stalenessPolicy,omitempty"`
+	// KVCacheThreshold is the KV cache usage, between 0 and 1, below which a pod without queue has
+	// capacity for sheddable requests.
+	KVCacheThreshold *float64 `json:"kvCacheThreshold,omitempty"`
+	// QueueThresholdCritical is the queue size up to which a pod has capacity for sheddable
+	// requests.
+	QueueThresholdCritical *int `json:"queueThresholdCritical,omitempty"`
+	// QueueingThresholdLoRA is the queue size below which LoRA affinity is prioritized.
+	QueueingThresholdLoRA *int `json:"queueingThresholdLoRA,omitempty"`
+	// Picker picks the target pod among the candidates, one of Random or LeastInFlightRequests.
+	Picker scheduling.Picker `json:"picker,omitempty"`
+}
+
+// MetricsConfig configures the scraping of the model server metrics.
+type MetricsConfig struct {
+	// RefreshInterval is the interval to refresh the metrics, see --refreshMetricsInterval.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// PrometheusRefreshInterval is the interval to flush the Prometheus metrics, see
+	// --refreshPrometheusMetricsInterval.
+	PrometheusRefreshInterval *metav1.Duration `json:"prometheusRefreshInterval,omitempty"`
+	// StalenessThreshold is the maximum age of pod metrics before they are considered stale, see
+	// --metricsStalenessThreshold. 0 disables staleness handling.
+	StalenessThreshold *metav1.Duration `json:"stalenessThreshold,omitempty"`
+	// RateWindow is the window over which rates and quantiles are derived, see --metricsRateWindow.
+	RateWindow *metav1.Duration `json:"rateWindow,omitempty"`
+	// ScrapeScheme is the URL scheme used to scrape the metrics, one of http or https.
+	ScrapeScheme string `json:"scrapeScheme,omitempty"`
+	// DiscoverModels enables the discovery of the models served by each pod, see --discoverModels.
+	DiscoverModels *bool `json:"discoverModels,omitempty"`
+	// RefreshModelsInterval is the interval to discover the served models, see
+	// --refreshModelsInterval.
+	RefreshModelsInterval *metav1.Duration `json:"refreshModelsInterval,omitempty"`
+}
+
+// PoolStatusConfig configures the Ready condition of the InferencePools.
+type PoolStatusConfig struct {
+	// Interval is the interval to update the condition, see --poolStatusInterval. 0 disables it.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// MinReadyPods is the minimum number of ready pods of a Ready pool.
+	MinReadyPods *int `json:"minReadyPods,omitempty"`
+	// MinFreshMetricsRatio is the minimum share of ready pods with fresh metrics of a Ready pool.
+	MinFreshMetricsRatio *float64 `json:"minFreshMetricsRatio,omitempty"`
+}
+
+// Load reads the config file at the given path, applies the overrides, if any, and returns the
+// defaulted and validated config, along with the content of the file it was parsed from. Without
+// path, the config only has the overrides and defaults, and the content is nil.
+func Load(path string, overrides func(*EndpointPickerConfig)) (*EndpointPickerConfig, []byte, error) {
+	cfg := &EndpointPickerConfig{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind}}
+	var data []byte
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if cfg, err = Parse(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	cfg, err := complete(cfg, overrides)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, data, nil
+}
+
+func complete(cfg *EndpointPickerConfig, overrides func(*EndpointPickerConfig)) (*EndpointPickerConfig, error) {
+	if overrides != nil {
+		overrides(cfg)
+	}
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Parse parses the given config file content. Unknown fields are rejected to catch typos. The
+// config is neither defaulted nor validated.
+func Parse(data []byte) (*EndpointPickerConfig, error) {
+	cfg := &EndpointPickerConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if cfg.APIVersion != APIVersion || cfg.Kind != Kind {
+		return nil, fmt.Errorf("unsupported config file apiVersion %q and kind %q, must be %s %s", cfg.APIVersion, cfg.Kind, APIVersion, Kind)
+	}
+	return cfg, nil
+}
+
+// SetDefaults sets the unset settings to the defaults of the corresponding flags.
+func (c *EndpointPickerConfig) SetDefaults() {
+	defaults := scheduling.DefaultConfig()
+	s := &c.Scheduling
+	if s.StalenessPolicy == "" {
+		s.StalenessPolicy = defaults.StalenessPolicy
+	}
+	setDefault(&s.KVCacheThreshold, defaults.KVCacheThreshold)
+	setDefault(&s.QueueThresholdCritical, defaults.QueueThresholdCritical)
+	setDefault(&s.QueueingThresholdLoRA, defaults.QueueingThresholdLoRA)
+	if s.Picker == "" {
+		s.Picker = defaults.Picker
+	}
+
+	m := &c.Metrics
+	setDefaultDuration(&m.RefreshInterval, runserver.DefaultRefreshMetricsInterval)
+	setDefaultDuration(&m.PrometheusRefreshInterval, runserver.DefaultRefreshPrometheusMetricsInterval)
+	setDefaultDuration(&m.StalenessThreshold, runserver.DefaultMetricsStalenessThreshold)
+	setDefaultDuration(&m.RateWindow, vllm.DefaultRateWindow)
+	if m.ScrapeScheme == "" {
+		m.ScrapeScheme = "http"
+	}
+	setDefault(&m.DiscoverModels, false)
+	setDefaultDuration(&m.RefreshModelsInterval, runserver.DefaultRefreshModelsInterval)
+
+	p := &c.PoolStatus
+	setDefaultDuration(&p.Interval, runserver.DefaultPoolStatusInterval)
+	setDefault(&p.MinReadyPods, runserver.DefaultPoolStatusMinReadyPods)
+	setDefault(&p.MinFreshMetricsRatio, runserver.DefaultPoolStatusMinFreshMetricsRatio)
+}
+
+// Validate returns an error if a setting of the defaulted config is invalid.
+func (c *EndpointPickerConfig) Validate() error {
+	s := &c.Scheduling
+	if _, err := scheduling.ParseStalenessPolicy(string(s.StalenessPolicy)); err != nil {
+		return fmt.Errorf("invalid scheduling.stalenessPolicy: %w", err)
+	}
+	if *s.KVCacheThreshold < 0 || *s.KVCacheThreshold > 1 {
+		return fmt.Errorf("invalid scheduling.kvCacheThreshold %v, must be between 0 and 1", *s.KVCacheThreshold)
+	}
+	if *s.QueueThresholdCritical < 0 {
+		return fmt.Errorf("invalid scheduling.queueThresholdCritical %d, must not be negative", *s.QueueThresholdCritical)
+	}
+	if *s.QueueingThresholdLoRA < 0 {
+		return fmt.Errorf("invalid scheduling.queueingThresholdLoRA %d, must not be negative", *s.QueueingThresholdLoRA)
+	}
+	if _, err := scheduling.ParsePicker(string(s.Picker)); err != nil {
+		return fmt.Errorf("invalid scheduling.picker: %w", err)
+	}
+
+	m := &c.Metrics
+	intervals := map[string]*metav1.Duration{
+		"metrics.refreshInterval":           m.RefreshInterval,
+		"metrics.prometheusRefreshInterval": m.PrometheusRefreshInterval,
+		"metrics.rateWindow":                m.RateWindow,
+		"metrics.refreshModelsInterval":     m.RefreshModelsInterval,
+	}
+	// Sorted so that the same invalid config always reports the same error.
+	for _, name := range slices.Sorted(maps.Keys(intervals)) {
+		if d := intervals[name]; d.Duration <= 0 {
+			return fmt.Errorf("invalid %s %v, must be positive", name, d.Duration)
+		}
+	}
+	if m.StalenessThreshold.Duration < 0 {
+		return fmt.Errorf("invalid metrics.stalenessThreshold %v, must not be negative", m.StalenessThreshold.Duration)
+	}
+	if m.ScrapeScheme != "http" && m.ScrapeScheme != "https" {
+		return fmt.Errorf("invalid metrics.scrapeScheme %q, must be http or https", m.ScrapeScheme)
+	}
+
+	p := &c.PoolStatus
+	if p.Interval.Duration < 0 {
+		return fmt.Errorf("invalid poolStatus.interval %v, must not be negative", p.Interval.Duration)
+	}
+	if *p.MinReadyPods < 0 {
+		return fmt.Errorf("invalid poolStatus.minReadyPods %d, must not be negative", *p.MinReadyPods)
+	}
+	if *p.MinFreshMetricsRatio < 0 || *p.MinFreshMetricsRatio > 1 {
+		return fmt.Errorf("invalid poolStatus.minFreshMetricsRatio %v, must be between 0 and 1", *p.MinFreshMetricsRatio)
+	}
+	return nil
+}
+
+// SchedulerConfig returns the config of the scheduler.
+func (c *EndpointPickerConfig) SchedulerConfig() scheduling.Config {
+	return scheduling.Config{
+		MetricsStalenessThreshold: c.Metrics.StalenessThreshold.Duration,
+		StalenessPolicy:           c.Scheduling.StalenessPolicy,
+		KVCacheThreshold:          *c.Scheduling.KVCacheThreshold,
+		QueueThresholdCritical:    *c.Scheduling.QueueThresholdCritical,
+		QueueingThresholdLoRA:     *c.Scheduling.QueueingThresholdLoRA,
+		Picker:                    c.Scheduling.Picker,
+	}
+}
+
+func setDefault[T any](field **T, value T) {
+	if *field == nil {
+		*field = ptr.To(value)
+	}
+}
+
+func setDefaultDuration(field **metav1.Duration, value time.Duration) {
+	if *field == nil {
+		*field = &metav1.Duration{Duration: value}
+	}
+}
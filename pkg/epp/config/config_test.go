@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+)
+
+const header = `
+apiVersion: config.inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+`
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		overrides func(*EndpointPickerConfig)
+		check     func(t *testing.T, cfg *EndpointPickerConfig)
+		wantErr   string
+	}{
+		{
+			name: "defaults",
+			data: header,
+			check: func(t *testing.T, cfg *EndpointPickerConfig) {
+				want := scheduling.DefaultConfig()
+				want.MetricsStalenessThreshold = runserver.DefaultMetricsStalenessThreshold
+				if diff := cmp.Diff(want, cfg.SchedulerConfig()); diff != "" {
+					t.Errorf("Unexpected scheduler config (-want +got): %s", diff)
+				}
+				if cfg.Metrics.ScrapeScheme != "http" || *cfg.Metrics.DiscoverModels {
+					t.Errorf("Unexpected metrics defaults: %+v", cfg.Metrics)
+				}
+				if *cfg.PoolStatus.MinReadyPods != runserver.DefaultPoolStatusMinReadyPods {
+					t.Errorf("Unexpected pool status defaults: %+v", cfg.PoolStatus)
+				}
+			},
+		},
+		{
+			name: "settings",
+			data: header + `
+scheduling:
+  stalenessPolicy: Exclude
+  kvCacheThreshold: 0.9
+  queueThresholdCritical: 3
+  queueingThresholdLoRA: 20
+  picker: LeastInFlightRequests
+metrics:
+  stalenessThreshold: 5s
+  scrapeScheme: https
+poolStatus:
+  minReadyPods: 2
+`,
+			check: func(t *testing.T, cfg *EndpointPickerConfig) {
+				want := scheduling.Config{
+					MetricsStalenessThreshold: 5 * time.Second,
+					StalenessPolicy:           scheduling.StalenessPolicyExclude,
+					KVCacheThreshold:          0.9,
+					QueueThresholdCritical:    3,
+					QueueingThresholdLoRA:     20,
+					Picker:                    scheduling.PickerLeastInFlightRequests,
+				}
+				if diff := cmp.Diff(want, cfg.SchedulerConfig()); diff != "" {
+					t.Errorf("Unexpected scheduler config (-want +got): %s", diff)
+				}
+				if cfg.Metrics.ScrapeScheme != "https" || *cfg.PoolStatus.MinReadyPods != 2 {
+					t.Errorf("Unexpected config: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "overrides take precedence",
+			data: header + `
+scheduling:
+  kvCacheThreshold: 0.9
+metrics:
+  stalenessThreshold: 5s
+`,
+			overrides: func(cfg *EndpointPickerConfig) {
+				cfg.Metrics.StalenessThreshold = &metav1.Duration{Duration: time.Second}
+			},
+			check: func(t *testing.T, cfg *EndpointPickerConfig) {
+				if cfg.Metrics.StalenessThreshold.Duration != time.Second || *cfg.Scheduling.KVCacheThreshold != 0.9 {
+					t.Errorf("Unexpected config: %+v", cfg)
+				}
+			},
+		},
+		{
+			name:    "unknown field",
+			data:    header + "scheduling:\n  kvCacheTreshold: 0.9\n",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "wrong kind",
+			data:    "apiVersion: " + APIVersion + "\nkind: Config\n",
+			wantErr: "unsupported config file",
+		},
+		{
+			name:    "invalid staleness policy",
+			data:    header + "scheduling:\n  stalenessPolicy: Ignore\n",
+			wantErr: "scheduling.stalenessPolicy",
+		},
+		{
+			name:    "invalid picker",
+			data:    header + "scheduling:\n  picker: RoundRobin\n",
+			wantErr: "scheduling.picker",
+		},
+		{
+			name:    "invalid kv cache threshold",
+			data:    header + "scheduling:\n  kvCacheThreshold: 1.5\n",
+			wantErr: "scheduling.kvCacheThreshold",
+		},
+		{
+			name:    "invalid scrape scheme",
+			data:    header + "metrics:\n  scrapeScheme: ftp\n",
+			wantErr: "metrics.scrapeScheme",
+		},
+		{
+			name:    "invalid refresh interval",
+			data:    header + "metrics:\n  refreshInterval: 0s\n",
+			wantErr: "metrics.refreshInterval",
+		},
+		{
+			name:    "several invalid intervals",
+			data:    header + "metrics:\n  refreshInterval: 0s\n  rateWindow: 0s\n",
+			wantErr: "metrics.rateWindow",
+		},
+		{
+			name:    "invalid min fresh metrics ratio",
+			data:    header + "poolStatus:\n  minFreshMetricsRatio: 2\n",
+			wantErr: "poolStatus.minFreshMetricsRatio",
+		},
+		{
+			name: "invalid override",
+			data: header,
+			overrides: func(cfg *EndpointPickerConfig) {
+				cfg.PoolStatus.MinReadyPods = ptr.To(-1)
+			},
+			wantErr: "poolStatus.minReadyPods",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(test.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, _, err := Load(path, test.overrides)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	cfg, data, err := Load("", func(cfg *EndpointPickerConfig) {
+		cfg.Scheduling.Picker = scheduling.PickerLeastInFlightRequests
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Scheduling.Picker != scheduling.PickerLeastInFlightRequests || *cfg.Scheduling.KVCacheThreshold != scheduling.DefaultKVCacheThreshold {
+		t.Errorf("Unexpected config: %+v", cfg.Scheduling)
+	}
+	if data != nil {
+		t.Errorf("Unexpected config file content without a file: %q", data)
+	}
+	if _, _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), nil); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
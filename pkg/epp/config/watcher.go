@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// DefaultRefreshInterval is the default interval of checking the config file for changes.
+const DefaultRefreshInterval = 2 * time.Second
+
+// Results of a config reload, recorded in the config reload metric.
+const (
+	ReloadSuccess = "success"
+	ReloadFailure = "failure"
+	// ReloadRestartRequired is the result of a reload changing settings that only take effect after
+	// a restart. The live settings of the reload are applied nevertheless.
+	ReloadRestartRequired = "restart_required"
+)
+
+// Watcher reloads the config file at Path whenever it changes, and applies the live settings of
+// valid changes. Invalid changes are logged and ignored, the last valid config remains in effect.
+//
+// The file is polled rather than watched for events, so that atomic replacements, e.g. of mounted
+// ConfigMaps, are picked up as well.
+type Watcher struct {
+	Path            string
+	RefreshInterval time.Duration
+	// Config is the config in effect, loaded from the file with the flag overrides applied.
+	Config *EndpointPickerConfig
+	// Data is the content of the file Config was loaded from, as returned by Load. Changes are
+	// detected against it, so that a change made after Config was loaded is applied as well.
+	Data []byte
+	// Overrides applies the explicitly set flags to a reloaded config, so that they keep taking
+	// precedence over the file.
+	Overrides func(*EndpointPickerConfig)
+	// Apply applies the live settings of a reloaded config.
+	Apply func(*EndpointPickerConfig)
+	// readFailed is true while the file cannot be read, so that the failure is only recorded once.
+	readFailed bool
+}
+
+// Start checks the config file for changes until the context is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	interval := w.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.Reload(ctx)
+		}
+	}
+}
+
+// Reload reads the config file and applies it if it changed since it was last loaded. It returns
+// the result of the reload, or an empty string if the file did not change.
+func (w *Watcher) Reload(ctx context.Context) string {
+	logger := log.FromContext(ctx).WithValues("path", w.Path)
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		if w.readFailed {
+			return ""
+		}
+		w.readFailed = true
+		logger.V(logutil.DEFAULT).Error(err, "Failed to read config file")
+		metrics.RecordConfigReload(ReloadFailure)
+		return ReloadFailure
+	}
+	w.readFailed = false
+	if bytes.Equal(data, w.Data) {
+		return ""
+	}
+	w.Data = data
+
+	cfg, err := Parse(data)
+	if err == nil {
+		cfg, err = complete(cfg, w.Overrides)
+	}
+	if err != nil {
+		logger.V(logutil.DEFAULT).Error(err, "Ignoring invalid config file change")
+		metrics.RecordConfigReload(ReloadFailure)
+		return ReloadFailure
+	}
+
+	result := ReloadSuccess
+	if !reflect.DeepEqual(cfg.Metrics, w.Config.Metrics) || !reflect.DeepEqual(cfg.PoolStatus, w.Config.PoolStatus) {
+		result = ReloadRestartRequired
+		logger.V(logutil.DEFAULT).Info("Config file changed settings that require a restart, applying the scheduling settings only")
+		// The settings in effect are kept, so that the next change is compared against them.
+		cfg.Metrics, cfg.PoolStatus = w.Config.Metrics, w.Config.PoolStatus
+	} else {
+		logger.V(logutil.DEFAULT).Info("Reloaded config file", "scheduling", cfg.Scheduling)
+	}
+	if w.Apply != nil {
+		w.Apply(cfg)
+	}
+	w.Config = cfg
+	metrics.RecordConfigReload(result)
+	return result
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+)
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(header + "metrics:\n  stalenessThreshold: 5s\n")
+	cfg, data, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var applied []scheduling.Config
+	w := &Watcher{
+		Path:   path,
+		Config: cfg,
+		Data:   data,
+		Overrides: func(cfg *EndpointPickerConfig) {
+			cfg.Scheduling.QueueThresholdCritical = nil
+		},
+		Apply: func(cfg *EndpointPickerConfig) {
+			applied = append(applied, cfg.SchedulerConfig())
+		},
+	}
+
+	steps := []struct {
+		name       string
+		data       string
+		remove     bool
+		wantResult string
+		wantKV     float64
+		wantPicker scheduling.Picker
+	}{
+		{
+			name:       "unchanged",
+			data:       header + "metrics:\n  stalenessThreshold: 5s\n",
+			wantResult: "",
+			wantKV:     scheduling.DefaultKVCacheThreshold,
+			wantPicker: scheduling.DefaultPicker,
+		},
+		{
+			name:       "scheduling change",
+			data:       header + "scheduling:\n  kvCacheThreshold: 0.5\n  queueThresholdCritical: 1\nmetrics:\n  stalenessThreshold: 5s\n",
+			wantResult: ReloadSuccess,
+			wantKV:     0.5,
+			wantPicker: scheduling.DefaultPicker,
+		},
+		{
+			name:       "invalid change",
+			data:       header + "scheduling:\n  picker: RoundRobin\n",
+			wantResult: ReloadFailure,
+			wantKV:     0.5,
+			wantPicker: scheduling.DefaultPicker,
+		},
+		{
+			name:       "restart required",
+			data:       header + "scheduling:\n  picker: LeastInFlightRequests\nmetrics:\n  stalenessThreshold: 1s\n",
+			wantResult: ReloadRestartRequired,
+			wantKV:     scheduling.DefaultKVCacheThreshold,
+			wantPicker: scheduling.PickerLeastInFlightRequests,
+		},
+		{
+			name:       "removed",
+			remove:     true,
+			wantResult: ReloadFailure,
+			wantKV:     scheduling.DefaultKVCacheThreshold,
+			wantPicker: scheduling.PickerLeastInFlightRequests,
+		},
+		{
+			name:       "still removed",
+			remove:     true,
+			wantResult: "",
+			wantKV:     scheduling.DefaultKVCacheThreshold,
+			wantPicker: scheduling.PickerLeastInFlightRequests,
+		},
+	}
+	for _, step := range steps {
+		if step.remove {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+		} else {
+			write(step.data)
+		}
+		if got := w.Reload(context.Background()); got != step.wantResult {
+			t.Errorf("%s: got result %q, want %q", step.name, got, step.wantResult)
+		}
+		if got := *w.Config.Scheduling.KVCacheThreshold; got != step.wantKV {
+			t.Errorf("%s: got kvCacheThreshold %v, want %v", step.name, got, step.wantKV)
+		}
+		if got := w.Config.Scheduling.Picker; got != step.wantPicker {
+			t.Errorf("%s: got picker %q, want %q", step.name, got, step.wantPicker)
+		}
+		if got := *w.Config.Scheduling.QueueThresholdCritical; got != scheduling.DefaultQueueThresholdCritical {
+			t.Errorf("%s: overridden queueThresholdCritical %d not kept", step.name, got)
+		}
+		// Settings requiring a restart keep their running values.
+		if got := w.Config.Metrics.StalenessThreshold.Duration; got != 5*time.Second {
+			t.Errorf("%s: got stalenessThreshold %v, want 5s", step.name, got)
+		}
+	}
+	if len(applied) != 2 {
+		t.Fatalf("Expected the live settings to be applied twice, got %d", len(applied))
+	}
+	if applied[1].MetricsStalenessThreshold != 5*time.Second || applied[1].Picker != scheduling.PickerLeastInFlightRequests {
+		t.Errorf("Unexpected applied config: %+v", applied[1])
+	}
+}
+
+func TestWatcherChangeAfterLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(header), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, data, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file changes between loading the config and starting the watcher.
+	if err := os.WriteFile(path, []byte(header+"scheduling:\n  kvCacheThreshold: 0.5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w := &Watcher{Path: path, Config: cfg, Data: data}
+	if got := w.Reload(context.Background()); got != ReloadSuccess {
+		t.Errorf("Got result %q, want %q", got, ReloadSuccess)
+	}
+	if got := *w.Config.Scheduling.KVCacheThreshold; got != 0.5 {
+		t.Errorf("Got kvCacheThreshold %v, want 0.5", got)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// EndpointSliceReconciler discovers the pods of the pools from the EndpointSlices of the Services
+// backing them, see datastore.ServiceAnnotation. It is an alternative to the PodReconciler that
+// avoids watching all pods of the cluster.
+type EndpointSliceReconciler struct {
+	client.Client
+	// Pools are the datastores of the InferencePools served by the EPP.
+	Pools  datastore.Pools
+	Scheme *runtime.Scheme
+	Record record.EventRecorder
+}
+
+// Reconcile updates the pods of the pools backed by the Service named by the request.
+func (c *EndpointSliceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	for name, ds := range c.Pools.InNamespace(req.Namespace) {
+		pool, err := ds.PoolGet()
+		if err != nil {
+			// When the inferencePool is initialized it lists the EndpointSlices and populates the datastore, so no need to requeue.
+			logger.V(logutil.TRACE).Info("Skipping InferencePool for reconciling EndpointSlices because it is not available yet", "pool", name)
+			continue
+		}
+		if datastore.PoolServiceName(pool) != req.Name {
+			continue
+		}
+		logger.V(logutil.VERBOSE).Info("EndpointSlices being reconciled", "service", req.NamespacedName, "pool", name)
+		if err := ResyncEndpointSlices(ctx, c.Client, ds); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Unable to resync EndpointSlices", "service", req.NamespacedName, "pool", name)
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (c *EndpointSliceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("endpointslice").
+		WithOptions(syncOptions()).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(serviceOfEndpointSlice)).
+		Complete(c)
+}
+
+// serviceOfEndpointSlice maps an EndpointSlice to the Service it belongs to.
+func serviceOfEndpointSlice(_ context.Context, obj client.Object) []reconcile.Request {
+	service := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if service == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: service}}}
+}
+
+// ResyncEndpointSlices adds, updates and removes the pods of the datastore to match the endpoints of
+// the EndpointSlices of the Service backing the pool. The pool must be synced.
+func ResyncEndpointSlices(ctx context.Context, c client.Reader, ds datastore.Datastore) error {
+	pool, err := ds.PoolGet()
+	if err != nil {
+		return err
+	}
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := c.List(ctx, slices, client.InNamespace(pool.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: datastore.PoolServiceName(pool)}); err != nil {
+		return fmt.Errorf("failed to list EndpointSlices: %w", err)
+	}
+
+	logger := log.FromContext(ctx)
+	endpoints := endpointsFromSlices(pool.Namespace, slices.Items)
+	for namespacedName, ep := range endpoints {
+		existing, exists := ds.PodGet(namespacedName)
+		if ep.draining && !exists {
+			// Draining pods are kept, but not added.
+			delete(endpoints, namespacedName)
+			continue
+		}
+		if ds.PodUpdateOrAddEndpointIfNotExist(namespacedName, ep.address, ep.draining) {
+			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName, "address", ep.address)
+		} else if ep.draining && !existing.Draining {
+			logger.V(logutil.DEFAULT).Info("Pod draining", "name", namespacedName,
+				"inFlightRequests", ds.PodInFlightRequests(namespacedName))
+		}
+	}
+	ds.PodRange(func(k, v any) bool {
+		namespacedName := k.(types.NamespacedName)
+		if _, ok := endpoints[namespacedName]; !ok {
+			logger.V(logutil.DEFAULT).Info("Pod removed", "name", namespacedName,
+				"inFlightRequests", ds.PodInFlightRequests(namespacedName))
+			ds.PodDelete(namespacedName)
+		}
+		return true
+	})
+	return nil
+}
+
+type endpoint struct {
+	address  string
+	ipv6     bool
+	draining bool
+}
+
+// endpointsFromSlices returns the pods backing the endpoints of the slices that should be in the
+// datastore:
+//   - Ready endpoints that are not terminating are active.
+//   - Serving endpoints that are terminating are draining.
+//   - All other endpoints are not ready to serve and are left out.
+//
+// Only endpoints referencing a pod are considered. Pods of dual-stack Services have an endpoint in
+// both an IPv4 and an IPv6 slice, the IPv4 address is used then. An endpoint that appears in
+// multiple slices of the same address family, e.g. while it moves between slices, is active if any
+// of them is.
+func endpointsFromSlices(namespace string, slices []discoveryv1.EndpointSlice) map[types.NamespacedName]endpoint {
+	res := map[types.NamespacedName]endpoint{}
+	for _, slice := range slices {
+		if slice.AddressType != discoveryv1.AddressTypeIPv4 && slice.AddressType != discoveryv1.AddressTypeIPv6 {
+			continue
+		}
+		ipv6 := slice.AddressType == discoveryv1.AddressTypeIPv6
+		for _, ep := range slice.Endpoints {
+			if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" || len(ep.Addresses) == 0 {
+				continue
+			}
+			// Per the EndpointSlice API, unknown ready and serving conditions are interpreted as true.
+			ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			serving := ready
+			if ep.Conditions.Serving != nil {
+				serving = *ep.Conditions.Serving
+			}
+			terminating := ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+			var draining bool
+			switch {
+			case ready && !terminating:
+				draining = false
+			case serving && terminating:
+				draining = true
+			default:
+				continue
+			}
+
+			namespacedName := types.NamespacedName{Namespace: namespace, Name: ep.TargetRef.Name}
+			if ep.TargetRef.Namespace != "" {
+				namespacedName.Namespace = ep.TargetRef.Namespace
+			}
+			new := endpoint{address: ep.Addresses[0], ipv6: ipv6, draining: draining}
+			existing, ok := res[namespacedName]
+			switch {
+			case !ok:
+			case existing.ipv6 != new.ipv6 && !new.ipv6:
+				// Prefer the IPv4 address of dual-stack pods.
+			case existing.ipv6 == new.ipv6 && existing.draining && !new.draining:
+			default:
+				continue
+			}
+			res[namespacedName] = new
+		}
+	}
+	return res
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+func makeEndpoint(pod, address string, ready, serving, terminating bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses: []string{address},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.To(ready),
+			Serving:     ptr.To(serving),
+			Terminating: ptr.To(terminating),
+		},
+		TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: pod},
+	}
+}
+
+func makeSlice(name, service string, addressType discoveryv1.AddressType, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: addressType,
+		Endpoints:   endpoints,
+	}
+}
+
+func TestEndpointsFromSlices(t *testing.T) {
+	podName := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "default", Name: name}
+	}
+	tests := []struct {
+		name   string
+		slices []*discoveryv1.EndpointSlice
+		want   map[types.NamespacedName]endpoint
+	}{
+		{
+			name: "Ready endpoints are active",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+				makeEndpoint("pod1", "10.0.0.1", true, true, false),
+				makeEndpoint("pod2", "10.0.0.2", true, true, false))},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "10.0.0.1"},
+				podName("pod2"): {address: "10.0.0.2"},
+			},
+		},
+		{
+			name: "Serving terminating endpoints are draining",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+				makeEndpoint("pod1", "10.0.0.1", false, true, true),
+				makeEndpoint("pod2", "10.0.0.2", false, false, true))},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "10.0.0.1", draining: true},
+			},
+		},
+		{
+			name: "Not ready endpoints are left out",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+				makeEndpoint("pod1", "10.0.0.1", false, false, false),
+				makeEndpoint("pod2", "10.0.0.2", true, true, false))},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod2"): {address: "10.0.0.2"},
+			},
+		},
+		{
+			name: "Unknown conditions are ready",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+				discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod1"}})},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "10.0.0.1"},
+			},
+		},
+		{
+			name: "Endpoints not referencing a pod are left out",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+				discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}},
+				discoveryv1.Endpoint{Addresses: []string{"10.0.0.2"}, TargetRef: &corev1.ObjectReference{Kind: "Node", Name: "node"}})},
+			want: map[types.NamespacedName]endpoint{},
+		},
+		{
+			name: "IPv6 endpoints",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeIPv6,
+				makeEndpoint("pod1", "fd00::1", true, true, false))},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "fd00::1", ipv6: true},
+			},
+		},
+		{
+			name: "Dual-stack endpoints prefer IPv4",
+			slices: []*discoveryv1.EndpointSlice{
+				makeSlice("s6", "svc", discoveryv1.AddressTypeIPv6,
+					makeEndpoint("pod1", "fd00::1", true, true, false)),
+				makeSlice("s4", "svc", discoveryv1.AddressTypeIPv4,
+					makeEndpoint("pod1", "10.0.0.1", true, true, false)),
+				makeSlice("s6-2", "svc", discoveryv1.AddressTypeIPv6,
+					makeEndpoint("pod1", "fd00::2", true, true, false)),
+			},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "10.0.0.1"},
+			},
+		},
+		{
+			name: "FQDN slices are ignored",
+			slices: []*discoveryv1.EndpointSlice{makeSlice("s1", "svc", discoveryv1.AddressTypeFQDN,
+				makeEndpoint("pod1", "pod1.example.com", true, true, false))},
+			want: map[types.NamespacedName]endpoint{},
+		},
+		{
+			name: "Endpoint in multiple slices is active if any is",
+			slices: []*discoveryv1.EndpointSlice{
+				makeSlice("s1", "svc", discoveryv1.AddressTypeIPv4,
+					makeEndpoint("pod1", "10.0.0.1", false, true, true)),
+				makeSlice("s2", "svc", discoveryv1.AddressTypeIPv4,
+					makeEndpoint("pod1", "10.0.0.1", true, true, false)),
+			},
+			want: map[types.NamespacedName]endpoint{
+				podName("pod1"): {address: "10.0.0.1"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var slices []discoveryv1.EndpointSlice
+			for _, s := range test.slices {
+				slices = append(slices, *s)
+			}
+			got := endpointsFromSlices("default", slices)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(endpoint{})); diff != "" {
+				t.Errorf("Unexpected endpoints (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcile_EndpointSliceReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pool := &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pool",
+			Namespace:   "default",
+			Annotations: map[string]string{datastore.ServiceAnnotation: "svc"},
+		},
+		Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	}
+	ds := datastore.NewFakeDatastore(nil, nil, pool)
+	reconciler := &EndpointSliceReconciler{
+		Pools: datastore.Pools{{Name: "pool", Namespace: "default"}: ds},
+	}
+	reconcile := func(step string, objs ...client.Object) {
+		reconciler.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "svc", Namespace: "default"}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Errorf("%s: unexpected EndpointSlice reconcile error: %v", step, err)
+		}
+	}
+	check := func(step string, want []datastore.Pod) {
+		var got []datastore.Pod
+		for _, pm := range ds.PodGetAll() {
+			got = append(got, pm.Pod)
+		}
+		sortPods := cmpopts.SortSlices(func(a, b datastore.Pod) bool { return a.NamespacedName.String() < b.NamespacedName.String() })
+		if diff := cmp.Diff(want, got, sortPods); diff != "" {
+			t.Errorf("%s: unexpected pods (-want +got): %s", step, diff)
+		}
+	}
+	pod := func(name, address string, draining bool) datastore.Pod {
+		return datastore.Pod{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+			Address:        address,
+			ScrapePath:     "/metrics",
+			ScrapePort:     8000,
+			Draining:       draining,
+		}
+	}
+	otherService := makeSlice("other", "other-svc", discoveryv1.AddressTypeIPv4,
+		makeEndpoint("other-pod", "10.0.1.1", true, true, false))
+
+	reconcile("pods added",
+		makeSlice("s4", "svc", discoveryv1.AddressTypeIPv4,
+			makeEndpoint("pod1", "10.0.0.1", true, true, false),
+			makeEndpoint("pod2", "10.0.0.2", false, true, true)),
+		makeSlice("s6", "svc", discoveryv1.AddressTypeIPv6,
+			makeEndpoint("pod3", "fd00::3", true, true, false)),
+		otherService)
+	// pod2 is not added since it is already draining.
+	check("pods added", []datastore.Pod{pod("pod1", "10.0.0.1", false), pod("pod3", "fd00::3", false)})
+
+	reconcile("pod draining",
+		makeSlice("s4", "svc", discoveryv1.AddressTypeIPv4,
+			makeEndpoint("pod1", "10.0.0.1", false, true, true)),
+		makeSlice("s6", "svc", discoveryv1.AddressTypeIPv6,
+			makeEndpoint("pod3", "fd00::3", true, true, false)),
+		otherService)
+	check("pod draining", []datastore.Pod{pod("pod1", "10.0.0.1", true), pod("pod3", "fd00::3", false)})
+
+	reconcile("pods removed",
+		makeSlice("s6", "svc", discoveryv1.AddressTypeIPv6,
+			makeEndpoint("pod3", "fd00::3", false, false, false)),
+		otherService)
+	check("pods removed", nil)
+}
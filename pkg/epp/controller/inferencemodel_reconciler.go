@@ -18,33 +18,48 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
 type InferenceModelReconciler struct {
 	client.Client
-	Scheme             *runtime.Scheme
-	Record             record.EventRecorder
-	Datastore          datastore.Datastore
-	PoolNamespacedName types.NamespacedName
+	Scheme *runtime.Scheme
+	Record record.EventRecorder
+	// Pools are the datastores of the InferencePools served by the EPP. An InferenceModel is added
+	// to the datastore of the pool it references. InferenceModels in other namespaces than the pool
+	// are only added if an InferencePoolGrant in the namespace of the pool allows it.
+	Pools datastore.Pools
+	// Elected is closed once the EPP is the elected leader. Only the leader writes the status of the
+	// InferenceModels, no status is written while it is nil. SetupWithManager sets it to the
+	// manager's Elected channel.
+	Elected <-chan struct{}
 }
 
 func (c *InferenceModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	if req.Namespace != c.PoolNamespacedName.Namespace {
-		return ctrl.Result{}, nil
-	}
-
 	logger := log.FromContext(ctx)
 	loggerDefault := logger.V(logutil.DEFAULT)
 	loggerDefault.Info("Reconciling InferenceModel", "name", req.NamespacedName)
@@ -53,37 +68,284 @@ func (c *InferenceModelReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err := c.Get(ctx, req.NamespacedName, infModel); err != nil {
 		if errors.IsNotFound(err) {
 			loggerDefault.Info("InferenceModel not found. Removing from datastore since object must be deleted", "name", req.NamespacedName)
-			c.Datastore.ModelDelete(infModel.Spec.ModelName)
+			c.deleteFromDatastores(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		loggerDefault.Error(err, "Unable to get InferenceModel", "name", req.NamespacedName)
 		return ctrl.Result{}, err
 	} else if !infModel.DeletionTimestamp.IsZero() {
 		loggerDefault.Info("InferenceModel is marked for deletion. Removing from datastore", "name", req.NamespacedName)
-		c.Datastore.ModelDelete(infModel.Spec.ModelName)
+		c.deleteFromDatastores(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
-	c.updateDatastore(logger, infModel)
+	poolName := datastore.PoolRefName(infModel)
+	if _, ok := c.Pools[poolName]; !ok {
+		// The model is not relevant to any pool, remove it in case it referenced one before.
+		c.updateDatastore(logger, infModel, nil)
+		return ctrl.Result{}, nil
+	}
+	if errs := datastore.ValidateInferenceModel(infModel); len(errs) > 0 {
+		// The model is not served until it is fixed, a new event is received then.
+		loggerDefault.Error(errs.ToAggregate(), "Ignoring invalid InferenceModel", "name", req.NamespacedName)
+		c.updateDatastore(logger, infModel, nil)
+		return ctrl.Result{}, nil
+	}
+
+	grants := &v1alpha1.InferencePoolGrantList{}
+	if err := c.List(ctx, grants, client.InNamespace(poolName.Namespace)); err != nil {
+		loggerDefault.Error(err, "Unable to list InferencePoolGrants", "name", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if !datastore.ReferenceGranted(infModel, grants.Items) {
+		// The model is served once a grant allows the reference, the grant triggers a new reconcile.
+		loggerDefault.Info("InferenceModel is not permitted to reference its InferencePool", "name", req.NamespacedName, "pool", poolName)
+		c.updateDatastore(logger, infModel, nil)
+		if err := c.updateStatus(ctx, infModel, nil); err != nil {
+			loggerDefault.Error(err, "Unable to update InferenceModel status", "name", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	accepted, err := c.acceptedModel(ctx, infModel, grants.Items)
+	if err != nil {
+		loggerDefault.Error(err, "Unable to list InferenceModels", "name", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	c.updateDatastore(logger, infModel, accepted)
+	if err := c.updateStatus(ctx, infModel, accepted); err != nil {
+		loggerDefault.Error(err, "Unable to update InferenceModel status", "name", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
-func (c *InferenceModelReconciler) updateDatastore(logger logr.Logger, infModel *v1alpha1.InferenceModel) {
+// acceptedModel returns the InferenceModel that is accepted for the model name of the given one
+// in the pool it references, see datastore.InferenceModelPrecedes. Invalid InferenceModels, and
+// InferenceModels not permitted to reference the pool by the grants in its namespace, are not
+// considered.
+func (c *InferenceModelReconciler) acceptedModel(ctx context.Context, infModel *v1alpha1.InferenceModel, grants []v1alpha1.InferencePoolGrant) (*v1alpha1.InferenceModel, error) {
+	models := &v1alpha1.InferenceModelList{}
+	if err := c.List(ctx, models); err != nil {
+		return nil, err
+	}
+	candidates := []*v1alpha1.InferenceModel{infModel}
+	for i := range models.Items {
+		model := &models.Items[i]
+		if !sameObject(model, infModel) && sameModelName(model, infModel) && model.DeletionTimestamp.IsZero() &&
+			len(datastore.ValidateInferenceModel(model)) == 0 && datastore.ReferenceGranted(model, grants) {
+			candidates = append(candidates, model)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return datastore.InferenceModelPrecedes(candidates[i], candidates[j])
+	})
+	return candidates[0], nil
+}
+
+// updateDatastore stores the accepted model of the pool referenced by the InferenceModel, and
+// removes the InferenceModel from all other pools and model names. A nil accepted model only
+// removes it.
+func (c *InferenceModelReconciler) updateDatastore(logger logr.Logger, infModel, accepted *v1alpha1.InferenceModel) {
 	loggerDefault := logger.V(logutil.DEFAULT)
+	namespacedName := types.NamespacedName{Name: infModel.Name, Namespace: infModel.Namespace}
+	refName := datastore.PoolRefName(infModel)
+
+	for poolName, ds := range c.Pools {
+		if accepted != nil && refName == poolName {
+			loggerDefault.Info("Updating datastore", "poolRef", infModel.Spec.PoolRef, "serverPoolName", poolName)
+			loggerDefault.Info("Adding/Updating InferenceModel", "modelName", accepted.Spec.ModelName, "name", accepted.Name)
+			ds.ModelSet(accepted)
+			// Remove the model names the InferenceModel was stored with before its model name changed.
+			for _, stored := range ds.ModelGetAll() {
+				if stored.Name == infModel.Name && stored.Namespace == infModel.Namespace && stored.Spec.ModelName != accepted.Spec.ModelName {
+					loggerDefault.Info("Removed InferenceModel", "modelName", stored.Spec.ModelName, "serverPoolName", poolName)
+					ds.ModelDelete(stored.Spec.ModelName)
+				}
+			}
+			continue
+		}
+		// The model is not relevant to this pool, remove it in case it referenced the pool before.
+		if ds.ModelDeleteByNamespacedName(namespacedName) {
+			loggerDefault.Info("Removed InferenceModel", "modelName", infModel.Spec.ModelName, "serverPoolName", poolName)
+		}
+	}
+}
+
+// updateStatus sets the Accepted and Ready conditions of the InferenceModel, depending on whether
+// it is the accepted model for its model name. A nil accepted model means that the InferenceModel
+// is not permitted to reference its pool. The status is only written by the leader.
+func (c *InferenceModelReconciler) updateStatus(ctx context.Context, infModel, accepted *v1alpha1.InferenceModel) error {
+	if !c.isLeader() {
+		return nil
+	}
+
+	poolName := datastore.PoolRefName(infModel)
+	status := metav1.ConditionTrue
+	acceptedReason, readyReason := v1alpha1.ModelReasonAccepted, v1alpha1.ModelReasonReady
+	message := fmt.Sprintf("Model %q is served by InferencePool %q", infModel.Spec.ModelName, poolName)
+	switch {
+	case accepted == nil:
+		status = metav1.ConditionFalse
+		acceptedReason, readyReason = v1alpha1.ModelReasonRefNotPermitted, v1alpha1.ModelReasonRefNotPermitted
+		message = fmt.Sprintf("No InferencePoolGrant in namespace %q allows InferenceModels in namespace %q to reference InferencePool %q",
+			poolName.Namespace, infModel.Namespace, poolName.Name)
+	case !sameObject(accepted, infModel):
+		status = metav1.ConditionFalse
+		acceptedReason, readyReason = v1alpha1.ModelReasonNameInUse, v1alpha1.ModelReasonNameInUse
+		message = fmt.Sprintf("Model %q is already used by the older InferenceModel %q in InferencePool %q",
+			infModel.Spec.ModelName, client.ObjectKeyFromObject(accepted), poolName)
+	}
 
-	if infModel.Spec.PoolRef.Name == c.PoolNamespacedName.Name {
-		loggerDefault.Info("Updating datastore", "poolRef", infModel.Spec.PoolRef, "serverPoolName", c.PoolNamespacedName)
-		loggerDefault.Info("Adding/Updating InferenceModel", "modelName", infModel.Spec.ModelName)
-		c.Datastore.ModelSet(infModel)
-		return
+	updated := infModel.DeepCopy()
+	meta.SetStatusCondition(&updated.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha1.ModelConditionAccepted),
+		Status:             status,
+		Reason:             string(acceptedReason),
+		Message:            message,
+		ObservedGeneration: infModel.Generation,
+	})
+	meta.SetStatusCondition(&updated.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha1.ModelConditionReady),
+		Status:             status,
+		Reason:             string(readyReason),
+		Message:            message,
+		ObservedGeneration: infModel.Generation,
+	})
+	if equality.Semantic.DeepEqual(updated.Status, infModel.Status) {
+		return nil
 	}
-	loggerDefault.Info("Removing/Not adding InferenceModel", "modelName", infModel.Spec.ModelName)
-	// If we get here. The model is not relevant to this pool, remove.
-	c.Datastore.ModelDelete(infModel.Spec.ModelName)
+	log.FromContext(ctx).V(logutil.DEFAULT).Info("Updating InferenceModel status", "name", infModel.Name,
+		"accepted", status, "reason", acceptedReason)
+	return c.Status().Patch(ctx, updated, client.MergeFrom(infModel))
 }
 
+func (c *InferenceModelReconciler) isLeader() bool {
+	if c.Elected == nil {
+		return false
+	}
+	select {
+	case <-c.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// deleteFromDatastores removes the model of the InferenceModel with the given name from all pools.
+func (c *InferenceModelReconciler) deleteFromDatastores(namespacedName types.NamespacedName) {
+	for _, ds := range c.Pools {
+		ds.ModelDeleteByNamespacedName(namespacedName)
+	}
+}
+
+// SetupWithManager registers the reconciler with the manager. It runs on all replicas to keep their
+// datastores in sync, whether or not they are the leader. Once elected, the leader reconciles all
+// InferenceModels again to write their status.
 func (c *InferenceModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if c.Elected == nil {
+		c.Elected = mgr.Elected()
+	}
+	elected := make(chan event.GenericEvent)
+	if err := mgr.Add(runnable.RequireLeaderElection(manager.RunnableFunc(func(ctx context.Context) error {
+		return c.enqueueAll(ctx, elected)
+	}))); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.InferenceModel{}).
+		Named("inferencemodel").
+		WithOptions(syncOptions()).
+		// Changes of an InferenceModel may change which of the InferenceModels with the same model
+		// name is accepted, so all of them are reconciled.
+		// Status updates, e.g. by the leader, are ignored.
+		Watches(&v1alpha1.InferenceModel{}, handler.EnqueueRequestsFromMapFunc(c.modelsWithSameName),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Changes of an InferencePoolGrant admit or evict the InferenceModels in other namespaces,
+		// which may change which InferenceModels are accepted in the pools of the grant.
+		Watches(&v1alpha1.InferencePoolGrant{}, handler.EnqueueRequestsFromMapFunc(c.modelsOfGrant),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(source.Channel(elected, &handler.EnqueueRequestForObject{})).
 		Complete(c)
 }
+
+// enqueueAll sends all InferenceModels of the served pools to the given channel once the EPP is
+// elected.
+func (c *InferenceModelReconciler) enqueueAll(ctx context.Context, ch chan<- event.GenericEvent) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-c.Elected:
+	}
+	models := &v1alpha1.InferenceModelList{}
+	if err := c.List(ctx, models); err != nil {
+		return fmt.Errorf("failed to list InferenceModels: %w", err)
+	}
+	for i := range models.Items {
+		if _, ok := c.Pools[datastore.PoolRefName(&models.Items[i])]; !ok {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case ch <- event.GenericEvent{Object: &models.Items[i]}:
+		}
+	}
+	return nil
+}
+
+// modelsWithSameName maps an InferenceModel to itself and the other InferenceModels with the same
+// model name in the same pool, in any namespace. InferenceModels referencing a pool that is not
+// served are ignored. When an InferenceModel stops referencing a served pool, its old version
+// still maps to the served pool, which removes it.
+func (c *InferenceModelReconciler) modelsWithSameName(ctx context.Context, obj client.Object) []reconcile.Request {
+	infModel, ok := obj.(*v1alpha1.InferenceModel)
+	if !ok {
+		return nil
+	}
+	if _, ok := c.Pools[datastore.PoolRefName(infModel)]; !ok {
+		return nil
+	}
+	requests := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: infModel.Namespace, Name: infModel.Name}}}
+	models := &v1alpha1.InferenceModelList{}
+	if err := c.List(ctx, models); err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Unable to list InferenceModels", "name", infModel.Name)
+		return requests
+	}
+	for _, model := range models.Items {
+		if !sameObject(&model, infModel) && sameModelName(&model, infModel) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: model.Namespace, Name: model.Name}})
+		}
+	}
+	return requests
+}
+
+// modelsOfGrant maps an InferencePoolGrant to the InferenceModels referencing the served pools in
+// its namespace. All of them are reconciled, not only the ones in other namespaces, since a model
+// admitted or evicted by the grant may change which InferenceModel is accepted for a model name.
+func (c *InferenceModelReconciler) modelsOfGrant(ctx context.Context, obj client.Object) []reconcile.Request {
+	if len(c.Pools.InNamespace(obj.GetNamespace())) == 0 {
+		return nil
+	}
+	models := &v1alpha1.InferenceModelList{}
+	if err := c.List(ctx, models); err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Unable to list InferenceModels", "grant", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range models.Items {
+		poolName := datastore.PoolRefName(&models.Items[i])
+		if _, ok := c.Pools[poolName]; ok && poolName.Namespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&models.Items[i])})
+		}
+	}
+	return requests
+}
+
+func sameModelName(a, b *v1alpha1.InferenceModel) bool {
+	return datastore.PoolRefName(a) == datastore.PoolRefName(b) && a.Spec.ModelName == b.Spec.ModelName
+}
+
+func sameObject(a, b client.Object) bool {
+	return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
@@ -20,11 +20,17 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -145,10 +151,9 @@ func TestUpdateDatastore_InferenceModelReconciler(t *testing.T) {
 				t.Fatalf("failed to get pool: %v", err)
 			}
 			reconciler := &InferenceModelReconciler{
-				Datastore:          test.datastore,
-				PoolNamespacedName: types.NamespacedName{Name: pool.Name},
+				Pools: datastore.Pools{types.NamespacedName{Name: pool.Name}: test.datastore},
 			}
-			reconciler.updateDatastore(logger, test.incomingService)
+			reconciler.updateDatastore(logger, test.incomingService, test.incomingService)
 
 			test.wantInferenceModels.Range(func(k, v any) bool {
 				_, exist := test.datastore.ModelGet(k.(string))
@@ -170,17 +175,16 @@ func TestReconcile_ResourceNotFound(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	// Create a minimal datastore.
-	datastore := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-pool"},
+	ds := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
 	})
 
 	// Create the reconciler.
 	reconciler := &InferenceModelReconciler{
-		Client:             fakeClient,
-		Scheme:             scheme,
-		Record:             record.NewFakeRecorder(10),
-		Datastore:          datastore,
-		PoolNamespacedName: types.NamespacedName{Name: "test-pool"},
+		Client: fakeClient,
+		Scheme: scheme,
+		Record: record.NewFakeRecorder(10),
+		Pools:  datastore.Pools{types.NamespacedName{Name: "test-pool", Namespace: "default"}: ds},
 	}
 
 	// Create a request for a non-existent resource.
@@ -222,17 +226,16 @@ func TestReconcile_ModelMarkedForDeletion(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingModel).Build()
 
 	// Create a minimal datastore.
-	datastore := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-pool"},
+	ds := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
 	})
 
 	// Create the reconciler.
 	reconciler := &InferenceModelReconciler{
-		Client:             fakeClient,
-		Scheme:             scheme,
-		Record:             record.NewFakeRecorder(10),
-		Datastore:          datastore,
-		PoolNamespacedName: types.NamespacedName{Name: "test-pool", Namespace: "default"},
+		Client: fakeClient,
+		Scheme: scheme,
+		Record: record.NewFakeRecorder(10),
+		Pools:  datastore.Pools{types.NamespacedName{Name: "test-pool", Namespace: "default"}: ds},
 	}
 
 	// Create a request for the existing resource.
@@ -250,7 +253,7 @@ func TestReconcile_ModelMarkedForDeletion(t *testing.T) {
 	}
 
 	// Verify that the datastore was not updated.
-	if _, exist := datastore.ModelGet(existingModel.Spec.ModelName); exist {
+	if _, exist := ds.ModelGet(existingModel.Spec.ModelName); exist {
 		t.Errorf("expected datastore to not contain model %q", existingModel.Spec.ModelName)
 	}
 }
@@ -276,17 +279,16 @@ func TestReconcile_ResourceExists(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingModel).Build()
 
 	// Create a minimal datastore.
-	datastore := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-pool"},
+	ds := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
 	})
 
 	// Create the reconciler.
 	reconciler := &InferenceModelReconciler{
-		Client:             fakeClient,
-		Scheme:             scheme,
-		Record:             record.NewFakeRecorder(10),
-		Datastore:          datastore,
-		PoolNamespacedName: types.NamespacedName{Name: "test-pool", Namespace: "default"},
+		Client: fakeClient,
+		Scheme: scheme,
+		Record: record.NewFakeRecorder(10),
+		Pools:  datastore.Pools{types.NamespacedName{Name: "test-pool", Namespace: "default"}: ds},
 	}
 
 	// Create a request for the existing resource.
@@ -304,11 +306,305 @@ func TestReconcile_ResourceExists(t *testing.T) {
 	}
 
 	// Verify that the datastore was updated.
-	if _, exist := datastore.ModelGet(existingModel.Spec.ModelName); !exist {
+	if _, exist := ds.ModelGet(existingModel.Spec.ModelName); !exist {
 		t.Errorf("expected datastore to contain model %q", existingModel.Spec.ModelName)
 	}
 }
 
+func TestReconcile_InferenceModelMultiplePools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+
+	newPool := func(name string) datastore.Datastore {
+		return datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		})
+	}
+	poolA, poolB := newPool("pool-a"), newPool("pool-b")
+	model := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "fake-model",
+			PoolRef:   v1alpha1.PoolObjectReference{Name: "pool-a"},
+		},
+	}
+	// Another InferenceModel registering the same model name in pool-b.
+	sameName := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "same-name", Namespace: "default"},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "fake-model",
+			PoolRef:   v1alpha1.PoolObjectReference{Name: "pool-b"},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(model, sameName).Build()
+	reconciler := &InferenceModelReconciler{
+		Client: fakeClient,
+		Scheme: scheme,
+		Pools: datastore.Pools{
+			{Name: "pool-a", Namespace: "default"}: poolA,
+			{Name: "pool-b", Namespace: "default"}: poolB,
+		},
+	}
+	reconcile := func(name string) {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Errorf("Unexpected InferenceModel reconcile error: %v", err)
+		}
+	}
+	modelObject := func(ds datastore.Datastore) string {
+		if m, ok := ds.ModelGet("fake-model"); ok {
+			return m.Name
+		}
+		return ""
+	}
+
+	reconcile("model")
+	reconcile("same-name")
+	if got := modelObject(poolA); got != "model" {
+		t.Errorf("Expected pool-a to serve the model of %q, got %q", "model", got)
+	}
+	if got := modelObject(poolB); got != "same-name" {
+		t.Errorf("Expected pool-b to serve the model of %q, got %q", "same-name", got)
+	}
+
+	// Deleting an InferenceModel only removes it from its pool.
+	if err := fakeClient.Delete(context.Background(), model); err != nil {
+		t.Fatalf("Unexpected InferenceModel delete error: %v", err)
+	}
+	reconcile("model")
+	if got := modelObject(poolA); got != "" {
+		t.Errorf("Expected pool-a to serve no model, got the model of %q", got)
+	}
+	if got := modelObject(poolB); got != "same-name" {
+		t.Errorf("Expected pool-b to serve the model of %q, got %q", "same-name", got)
+	}
+}
+
+func TestReconcile_InferenceModelDuplicateModelNames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+
+	ds := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
+	})
+	newModel := func(name string, created time.Time) *v1alpha1.InferenceModel {
+		return &v1alpha1.InferenceModel{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1, CreationTimestamp: metav1.NewTime(created)},
+			Spec: v1alpha1.InferenceModelSpec{
+				ModelName: "fake-model",
+				PoolRef:   v1alpha1.PoolObjectReference{Name: "test-pool"},
+			},
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+	// The older model wins, even though its name sorts last.
+	older, newer := newModel("older", now.Add(-time.Minute)), newModel("a-newer", now)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(older, newer).
+		WithStatusSubresource(&v1alpha1.InferenceModel{}).
+		Build()
+	elected := make(chan struct{})
+	reconciler := &InferenceModelReconciler{
+		Client:  fakeClient,
+		Scheme:  scheme,
+		Pools:   datastore.Pools{{Name: "test-pool", Namespace: "default"}: ds},
+		Elected: elected,
+	}
+	reconcile := func(name string) {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Errorf("Unexpected InferenceModel reconcile error: %v", err)
+		}
+	}
+	checkStored := func(step, want string) {
+		t.Helper()
+		got := ""
+		if m, ok := ds.ModelGet("fake-model"); ok {
+			got = m.Name
+		}
+		if got != want {
+			t.Errorf("%s: expected the datastore to serve the model of %q, got %q", step, want, got)
+		}
+	}
+	checkConditions := func(step, name string, want []metav1.Condition) {
+		t.Helper()
+		model := &v1alpha1.InferenceModel{}
+		if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, model); err != nil {
+			t.Fatalf("%s: unexpected InferenceModel get error: %v", step, err)
+		}
+		if diff := cmp.Diff(want, model.Status.Conditions,
+			cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime")); diff != "" {
+			t.Errorf("%s: unexpected conditions of %q (-want +got): %s", step, name, diff)
+		}
+	}
+	conditions := func(status metav1.ConditionStatus, acceptedReason, readyReason v1alpha1.InferenceModelConditionReason) []metav1.Condition {
+		return []metav1.Condition{
+			{Type: string(v1alpha1.ModelConditionAccepted), Status: status, Reason: string(acceptedReason), ObservedGeneration: 1},
+			{Type: string(v1alpha1.ModelConditionReady), Status: status, Reason: string(readyReason), ObservedGeneration: 1},
+		}
+	}
+
+	// Followers update their datastore, but do not write status.
+	reconcile("a-newer")
+	reconcile("older")
+	checkStored("follower", "older")
+	checkConditions("follower", "older", nil)
+	checkConditions("follower", "a-newer", nil)
+
+	close(elected)
+	reconcile("a-newer")
+	reconcile("older")
+	checkStored("leader", "older")
+	checkConditions("leader", "older", conditions(metav1.ConditionTrue, v1alpha1.ModelReasonAccepted, v1alpha1.ModelReasonReady))
+	checkConditions("leader", "a-newer", conditions(metav1.ConditionFalse, v1alpha1.ModelReasonNameInUse, v1alpha1.ModelReasonNameInUse))
+
+	// Once the older model is deleted, the newer one takes over.
+	if err := fakeClient.Delete(context.Background(), older); err != nil {
+		t.Fatalf("Unexpected InferenceModel delete error: %v", err)
+	}
+	reconcile("older")
+	reconcile("a-newer")
+	checkStored("deleted", "a-newer")
+	checkConditions("deleted", "a-newer", conditions(metav1.ConditionTrue, v1alpha1.ModelReasonAccepted, v1alpha1.ModelReasonReady))
+}
+
+func TestReconcile_InferenceModelCrossNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+
+	ds := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "platform"},
+	})
+	now := time.Now().Truncate(time.Second)
+	// The model of the team is older, it is accepted while it is granted.
+	team := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "team", Generation: 1, CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "fake-model",
+			PoolRef:   v1alpha1.PoolObjectReference{Namespace: "platform", Name: "test-pool"},
+		},
+	}
+	platform := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "platform", Generation: 1, CreationTimestamp: metav1.NewTime(now)},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "fake-model",
+			PoolRef:   v1alpha1.PoolObjectReference{Name: "test-pool"},
+		},
+	}
+	grant := &v1alpha1.InferencePoolGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "platform"},
+		Spec: v1alpha1.InferencePoolGrantSpec{
+			From: []v1alpha1.InferencePoolGrantFrom{{Namespace: "team"}},
+			To:   []v1alpha1.InferencePoolGrantTo{{}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(team, platform).
+		WithStatusSubresource(&v1alpha1.InferenceModel{}).
+		Build()
+	elected := make(chan struct{})
+	close(elected)
+	reconciler := &InferenceModelReconciler{
+		Client:  fakeClient,
+		Scheme:  scheme,
+		Pools:   datastore.Pools{{Name: "test-pool", Namespace: "platform"}: ds},
+		Elected: elected,
+	}
+	reconcileAll := func() {
+		t.Helper()
+		for _, model := range []*v1alpha1.InferenceModel{team, platform} {
+			if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(model)}); err != nil {
+				t.Errorf("Unexpected InferenceModel reconcile error: %v", err)
+			}
+		}
+	}
+	check := func(step string, wantStored types.NamespacedName, wantTeamReason, wantPlatformReason v1alpha1.InferenceModelConditionReason) {
+		t.Helper()
+		var got types.NamespacedName
+		if m, ok := ds.ModelGet("fake-model"); ok {
+			got = client.ObjectKeyFromObject(m)
+		}
+		if got != wantStored {
+			t.Errorf("%s: expected the datastore to serve the model of %v, got %v", step, wantStored, got)
+		}
+		for model, want := range map[*v1alpha1.InferenceModel]v1alpha1.InferenceModelConditionReason{team: wantTeamReason, platform: wantPlatformReason} {
+			stored := &v1alpha1.InferenceModel{}
+			if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(model), stored); err != nil {
+				t.Fatalf("%s: unexpected InferenceModel get error: %v", step, err)
+			}
+			cond := meta.FindStatusCondition(stored.Status.Conditions, string(v1alpha1.ModelConditionAccepted))
+			if cond == nil || cond.Reason != string(want) {
+				t.Errorf("%s: expected the Accepted reason of %v to be %s, got %+v", step, client.ObjectKeyFromObject(model), want, cond)
+			}
+		}
+	}
+
+	reconcileAll()
+	check("not granted", client.ObjectKeyFromObject(platform), v1alpha1.ModelReasonRefNotPermitted, v1alpha1.ModelReasonAccepted)
+
+	if err := fakeClient.Create(context.Background(), grant); err != nil {
+		t.Fatalf("Unexpected InferencePoolGrant create error: %v", err)
+	}
+	wantRequests := []reconcile.Request{
+		{NamespacedName: client.ObjectKeyFromObject(platform)},
+		{NamespacedName: client.ObjectKeyFromObject(team)},
+	}
+	if diff := cmp.Diff(wantRequests, reconciler.modelsOfGrant(context.Background(), grant)); diff != "" {
+		t.Errorf("Unexpected requests of the grant (-want +got): %s", diff)
+	}
+	reconcileAll()
+	check("granted", client.ObjectKeyFromObject(team), v1alpha1.ModelReasonAccepted, v1alpha1.ModelReasonNameInUse)
+
+	if err := fakeClient.Delete(context.Background(), grant); err != nil {
+		t.Fatalf("Unexpected InferencePoolGrant delete error: %v", err)
+	}
+	reconcileAll()
+	check("revoked", client.ObjectKeyFromObject(platform), v1alpha1.ModelReasonRefNotPermitted, v1alpha1.ModelReasonAccepted)
+}
+
+func TestModelsWithSameName(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+
+	newModel := func(name, modelName, pool string) *v1alpha1.InferenceModel {
+		return &v1alpha1.InferenceModel{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.InferenceModelSpec{ModelName: modelName, PoolRef: v1alpha1.PoolObjectReference{Name: pool}},
+		}
+	}
+	model := newModel("model", "fake-model", "test-pool")
+	crossNamespace := newModel("cross-namespace", "fake-model", "test-pool")
+	crossNamespace.Namespace, crossNamespace.Spec.PoolRef.Namespace = "team", "default"
+	otherNamespace := newModel("other-namespace", "fake-model", "test-pool")
+	otherNamespace.Namespace = "team"
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		model,
+		newModel("same-name", "fake-model", "test-pool"),
+		newModel("other-name", "other-model", "test-pool"),
+		newModel("other-pool", "fake-model", "other-pool"),
+		crossNamespace,
+		otherNamespace,
+	).Build()
+	reconciler := &InferenceModelReconciler{
+		Client: fakeClient,
+		Pools: datastore.Pools{{Name: "test-pool", Namespace: "default"}: datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
+		})},
+	}
+
+	got := reconciler.modelsWithSameName(context.Background(), model)
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "model", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "same-name", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "cross-namespace", Namespace: "team"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected requests (-want +got): %s", diff)
+	}
+}
+
 func populateServiceMap(services ...*v1alpha1.InferenceModel) *sync.Map {
 	returnVal := &sync.Map{}
 
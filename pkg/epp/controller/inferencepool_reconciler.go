@@ -18,16 +18,23 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"sync"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -37,14 +44,31 @@ import (
 // will have the proper controller that will create/manage objects on behalf of the server pool.
 type InferencePoolReconciler struct {
 	client.Client
-	Scheme             *runtime.Scheme
-	Record             record.EventRecorder
-	PoolNamespacedName types.NamespacedName
-	Datastore          datastore.Datastore
+	Scheme *runtime.Scheme
+	Record record.EventRecorder
+	// Pools are the datastores of the InferencePools served by the EPP, other pools are ignored.
+	Pools datastore.Pools
+	// EndpointSlices is true if pods are discovered from EndpointSlices by the
+	// EndpointSliceReconciler rather than from Pods by the PodReconciler.
+	EndpointSlices bool
+	// PoolStatus configures the Ready condition written on the pools by the elected leader.
+	PoolStatus PoolStatusConfig
+	// PodCacheSelectors are the label selectors restricting the pods cached per namespace, if any.
+	// The selector of a namespace is the selector its pools had when the cache was created. If the
+	// selector of a pool changes, its pods may not be cached any more, and the manager is stopped so
+	// that the EPP restarts with a new cache.
+	PodCacheSelectors map[string]labels.Selector
+
+	podCacheStale     chan struct{}
+	podCacheStaleOnce sync.Once
 }
 
+// errPodCacheStale stops the manager when the pod cache no longer covers the pools.
+var errPodCacheStale = errors.New("the selector of an InferencePool changed since the pod cache was created")
+
 func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	if req.NamespacedName.Name != c.PoolNamespacedName.Name || req.NamespacedName.Namespace != c.PoolNamespacedName.Namespace {
+	ds, ok := c.Pools[req.NamespacedName]
+	if !ok {
 		return ctrl.Result{}, nil
 	}
 
@@ -55,42 +79,103 @@ func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	serverPool := &v1alpha1.InferencePool{}
 
 	if err := c.Get(ctx, req.NamespacedName, serverPool); err != nil {
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			loggerDefault.Info("InferencePool not found. Clearing the datastore", "name", req.NamespacedName)
-			c.Datastore.Clear()
+			ds.Clear()
 			return ctrl.Result{}, nil
 		}
 		loggerDefault.Error(err, "Unable to get InferencePool", "name", req.NamespacedName)
 		return ctrl.Result{}, err
 	} else if !serverPool.DeletionTimestamp.IsZero() {
 		loggerDefault.Info("InferencePool is marked for deletion. Clearing the datastore", "name", req.NamespacedName)
-		c.Datastore.Clear()
+		ds.Clear()
+		return ctrl.Result{}, nil
+	}
+
+	if errs := datastore.ValidateInferencePool(serverPool); len(errs) > 0 {
+		// The pool is not updated until it is fixed, a new event is received then.
+		loggerDefault.Error(errs.ToAggregate(), "Ignoring invalid InferencePool", "name", req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
-	c.updateDatastore(ctx, serverPool)
+	c.checkPodCache(ctx, serverPool)
+	c.updateDatastore(ctx, ds, serverPool)
 
 	return ctrl.Result{}, nil
 }
 
-func (c *InferencePoolReconciler) updateDatastore(ctx context.Context, newPool *v1alpha1.InferencePool) {
+// checkPodCache stops the manager if the pool selects pods that may not be cached.
+func (c *InferencePoolReconciler) checkPodCache(ctx context.Context, pool *v1alpha1.InferencePool) {
+	cached, ok := c.PodCacheSelectors[pool.Namespace]
+	if !ok {
+		return
+	}
+	if selector, err := datastore.PoolSelector(pool); err == nil && selector.String() == cached.String() {
+		return
+	}
+	log.FromContext(ctx).V(logutil.DEFAULT).Error(errPodCacheStale, "Restarting to update the pod cache",
+		"name", pool.Name, "cachedSelector", cached.String())
+	c.podCacheStaleOnce.Do(func() {
+		if c.podCacheStale != nil {
+			close(c.podCacheStale)
+		}
+	})
+}
+
+func (c *InferencePoolReconciler) updateDatastore(ctx context.Context, ds datastore.Datastore, newPool *v1alpha1.InferencePool) {
 	logger := log.FromContext(ctx)
-	oldPool, err := c.Datastore.PoolGet()
-	c.Datastore.PoolSet(newPool)
-	if err != nil || !reflect.DeepEqual(newPool.Spec.Selector, oldPool.Spec.Selector) {
-		logger.V(logutil.DEFAULT).Info("Updating inference pool endpoints", "selector", newPool.Spec.Selector)
-		// A full resync is required to address two cases:
+	oldPool, err := ds.PoolGet()
+	ds.PoolSet(newPool)
+	if err != nil || !reflect.DeepEqual(newPool.Spec.Selector, oldPool.Spec.Selector) ||
+		!reflect.DeepEqual(newPool.Spec.LabelSelector, oldPool.Spec.LabelSelector) ||
+		newPool.Annotations[datastore.MetricsSchemeAnnotation] != oldPool.Annotations[datastore.MetricsSchemeAnnotation] ||
+		datastore.PoolServiceName(newPool) != datastore.PoolServiceName(oldPool) {
+		logger.V(logutil.DEFAULT).Info("Updating inference pool endpoints", "selector", newPool.Spec.Selector, "labelSelector", newPool.Spec.LabelSelector)
+		// A full resync is required to address three cases:
 		// 1) At startup, the pod events may get processed before the pool is synced with the datastore,
 		//    and hence they will not be added to the store since pool selector is not known yet
 		// 2) If the selector on the pool was updated, then we will not get any pod events, and so we need
 		//    to resync the whole pool: remove pods in the store that don't match the new selector and add
 		//    the ones that may have existed already to the store.
-		c.Datastore.PodResyncAll(ctx, c.Client)
+		// 3) If the metrics scheme of the pool was updated, the scrape options of all pods need to be
+		//    updated as well.
+		if c.EndpointSlices {
+			if err := ResyncEndpointSlices(ctx, c.Client, ds); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "Unable to resync EndpointSlices", "pool", newPool.Name)
+			}
+		} else {
+			ds.PodResyncAll(ctx, c.Client)
+		}
 	}
 }
 
 func (c *InferencePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if c.PoolStatus.Interval > 0 {
+		if err := mgr.Add(runnable.RequireLeaderElection(manager.RunnableFunc(c.runStatusUpdates))); err != nil {
+			return err
+		}
+	}
+	if len(c.PodCacheSelectors) > 0 {
+		c.podCacheStale = make(chan struct{})
+		if err := mgr.Add(runnable.NoLeaderElection(manager.RunnableFunc(func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-c.podCacheStale:
+				return errPodCacheStale
+			}
+		}))); err != nil {
+			return err
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.InferencePool{}).
+		For(&v1alpha1.InferencePool{}, builder.WithPredicates(specOrAnnotationChanged())).
+		WithOptions(syncOptions()).
 		Complete(c)
 }
+
+// syncOptions are the options of the controllers syncing the datastores. They run on all replicas,
+// not only on the elected leader, since every replica serves requests from its own datastores.
+func syncOptions() controller.Options {
+	return controller.Options{NeedLeaderElection: ptr.To(false)}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -91,14 +92,14 @@ func TestReconcile_InferencePoolReconciler(t *testing.T) {
 	req := ctrl.Request{NamespacedName: namespacedName}
 	ctx := context.Background()
 
-	datastore := datastore.NewDatastore()
-	inferencePoolReconciler := &InferencePoolReconciler{PoolNamespacedName: namespacedName, Client: fakeClient, Datastore: datastore}
+	ds := datastore.NewDatastore()
+	inferencePoolReconciler := &InferencePoolReconciler{Client: fakeClient, Pools: datastore.Pools{namespacedName: ds}}
 
 	// Step 1: Inception, only ready pods matching pool1 are added to the store.
 	if _, err := inferencePoolReconciler.Reconcile(ctx, req); err != nil {
 		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
 	}
-	if diff := diffPool(datastore, pool1, []string{"pod1", "pod2"}); diff != "" {
+	if diff := diffPool(ds, pool1, []string{"pod1", "pod2"}); diff != "" {
 		t.Errorf("Unexpected diff (+got/-want): %s", diff)
 	}
 
@@ -106,7 +107,7 @@ func TestReconcile_InferencePoolReconciler(t *testing.T) {
 	if _, err := inferencePoolReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: pool2.Name, Namespace: pool2.Namespace}}); err != nil {
 		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
 	}
-	if diff := diffPool(datastore, pool1, []string{"pod1", "pod2"}); diff != "" {
+	if diff := diffPool(ds, pool1, []string{"pod1", "pod2"}); diff != "" {
 		t.Errorf("Unexpected diff (+got/-want): %s", diff)
 	}
 
@@ -123,7 +124,7 @@ func TestReconcile_InferencePoolReconciler(t *testing.T) {
 	if _, err := inferencePoolReconciler.Reconcile(ctx, req); err != nil {
 		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
 	}
-	if diff := diffPool(datastore, newPool1, []string{"pod5"}); diff != "" {
+	if diff := diffPool(ds, newPool1, []string{"pod5"}); diff != "" {
 		t.Errorf("Unexpected diff (+got/-want): %s", diff)
 	}
 
@@ -138,7 +139,7 @@ func TestReconcile_InferencePoolReconciler(t *testing.T) {
 	if _, err := inferencePoolReconciler.Reconcile(ctx, req); err != nil {
 		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
 	}
-	if diff := diffPool(datastore, newPool1, []string{"pod5"}); diff != "" {
+	if diff := diffPool(ds, newPool1, []string{"pod5"}); diff != "" {
 		t.Errorf("Unexpected diff (+got/-want): %s", diff)
 	}
 
@@ -152,11 +153,53 @@ func TestReconcile_InferencePoolReconciler(t *testing.T) {
 	if _, err := inferencePoolReconciler.Reconcile(ctx, req); err != nil {
 		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
 	}
-	if diff := diffPool(datastore, nil, []string{}); diff != "" {
+	if diff := diffPool(ds, nil, []string{}); diff != "" {
 		t.Errorf("Unexpected diff (+got/-want): %s", diff)
 	}
 }
 
+func TestReconcile_PodCacheStale(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool1).Build()
+
+	namespacedName := types.NamespacedName{Name: pool1.Name, Namespace: pool1.Namespace}
+	req := ctrl.Request{NamespacedName: namespacedName}
+	ctx := context.Background()
+	reconciler := &InferencePoolReconciler{
+		Client:            fakeClient,
+		Pools:             datastore.Pools{namespacedName: datastore.NewDatastore()},
+		PodCacheSelectors: map[string]labels.Selector{pool1.Namespace: labels.SelectorFromSet(selector_v1)},
+		podCacheStale:     make(chan struct{}),
+	}
+
+	// The cache selector matches the pool.
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
+	}
+	select {
+	case <-reconciler.podCacheStale:
+		t.Fatal("Expected the pod cache to be up to date")
+	default:
+	}
+
+	// The pool selects other pods than the cache.
+	newPool1 := pool1.DeepCopy()
+	newPool1.Spec.Selector = map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm_v2"}
+	if err := fakeClient.Update(ctx, newPool1); err != nil {
+		t.Fatalf("Unexpected pool update error: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Errorf("Unexpected InferencePool reconcile error: %v", err)
+	}
+	select {
+	case <-reconciler.podCacheStale:
+	default:
+		t.Fatal("Expected the pod cache to be stale")
+	}
+}
+
 func diffPool(datastore datastore.Datastore, wantPool *v1alpha1.InferencePool, wantPods []string) string {
 	gotPool, _ := datastore.PoolGet()
 	if diff := cmp.Diff(wantPool, gotPool); diff != "" {
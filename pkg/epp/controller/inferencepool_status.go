@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// PoolStatusConfig configures the Ready condition the elected EPP writes on the InferencePools it
+// serves.
+type PoolStatusConfig struct {
+	// Interval is the interval of updating the status. The status is not written if it is not
+	// positive.
+	Interval time.Duration
+	// MinReadyPods is the minimum number of ready pods, not counting draining pods, for the pool to
+	// be ready.
+	MinReadyPods int
+	// MinFreshMetricsRatio is the minimum share of the ready pods with fresh metrics for the pool to
+	// be ready, between 0 and 1.
+	MinFreshMetricsRatio float64
+	// MetricsStalenessThreshold is the age after which the metrics of a pod are not fresh anymore.
+	// The metrics of a pod are fresh once scraped if it is not positive.
+	MetricsStalenessThreshold time.Duration
+}
+
+// evaluate returns the Ready condition of a pool with the given pods.
+func (s PoolStatusConfig) evaluate(pods []*datastore.PodMetrics, now time.Time) metav1.Condition {
+	ready, fresh := datastore.CountReadyPods(pods, s.MetricsStalenessThreshold, now)
+
+	condition := metav1.Condition{
+		Type:    string(v1alpha1.PoolConditionReady),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1alpha1.PoolReasonEPPNotHealthy),
+		Message: fmt.Sprintf("%d ready pods, %d with fresh metrics", ready, fresh),
+	}
+	switch {
+	case ready < s.MinReadyPods:
+		condition.Message = fmt.Sprintf("%d ready pods, at least %d required", ready, s.MinReadyPods)
+	case ready > 0 && float64(fresh)/float64(ready) < s.MinFreshMetricsRatio:
+		condition.Message = fmt.Sprintf("%d of %d ready pods have fresh metrics, at least %.0f%% required",
+			fresh, ready, s.MinFreshMetricsRatio*100)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(v1alpha1.PoolReasonReady)
+	}
+	return condition
+}
+
+// runStatusUpdates updates the Ready condition of the served pools every PoolStatus.Interval until
+// the context is done. It only runs on the elected leader.
+func (c *InferencePoolReconciler) runStatusUpdates(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	ticker := time.NewTicker(c.PoolStatus.Interval)
+	defer ticker.Stop()
+	for {
+		for name, ds := range c.Pools {
+			if err := c.updateStatus(ctx, ds); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "Unable to update InferencePool status", "name", name)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateStatus patches the Ready condition of the pool of the datastore if it changed, and records
+// an event when the pool becomes ready or not ready. Pools that have not synced are skipped, their
+// condition remains Pending.
+func (c *InferencePoolReconciler) updateStatus(ctx context.Context, ds datastore.Datastore) error {
+	pool, err := ds.PoolGet()
+	if err != nil {
+		return nil
+	}
+	condition := c.PoolStatus.evaluate(ds.PodGetAll(), time.Now())
+	condition.ObservedGeneration = pool.Generation
+
+	updated := pool.DeepCopy()
+	if !meta.SetStatusCondition(&updated.Status.Conditions, condition) {
+		return nil
+	}
+	// The pool of the datastore may lag behind, the optimistic lock avoids overwriting the
+	// conditions written by others.
+	if err := c.Status().Patch(ctx, updated, client.MergeFromWithOptions(pool, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
+	}
+
+	if previous := meta.FindStatusCondition(pool.Status.Conditions, condition.Type); previous == nil || previous.Status != condition.Status {
+		log.FromContext(ctx).V(logutil.DEFAULT).Info("InferencePool readiness changed", "name", pool.Name,
+			"status", condition.Status, "reason", condition.Reason, "message", condition.Message)
+		if c.Record != nil {
+			eventType := corev1.EventTypeNormal
+			if condition.Status != metav1.ConditionTrue {
+				eventType = corev1.EventTypeWarning
+			}
+			c.Record.Event(updated, eventType, condition.Reason, condition.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+func TestPoolStatusEvaluate(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, updated time.Duration, draining bool) *datastore.PodMetrics {
+		pm := &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: name}, Draining: draining}}
+		if updated >= 0 {
+			pm.UpdateTime = now.Add(-updated)
+		}
+		return pm
+	}
+	config := PoolStatusConfig{MinReadyPods: 2, MinFreshMetricsRatio: 0.5, MetricsStalenessThreshold: time.Second}
+	tests := []struct {
+		name        string
+		config      PoolStatusConfig
+		pods        []*datastore.PodMetrics
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name:        "Ready",
+			config:      config,
+			pods:        []*datastore.PodMetrics{pod("pod1", 0, false), pod("pod2", 2*time.Second, false)},
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "2 ready pods, 1 with fresh metrics",
+		},
+		{
+			name:        "Too few ready pods",
+			config:      config,
+			pods:        []*datastore.PodMetrics{pod("pod1", 0, false), pod("pod2", 0, true)},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "1 ready pods, at least 2 required",
+		},
+		{
+			name:        "Too few fresh metrics",
+			config:      config,
+			pods:        []*datastore.PodMetrics{pod("pod1", 2*time.Second, false), pod("pod2", -1, false), pod("pod3", 0, false)},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "1 of 3 ready pods have fresh metrics, at least 50% required",
+		},
+		{
+			name:        "Metrics are fresh once scraped without a staleness threshold",
+			config:      PoolStatusConfig{MinReadyPods: 1, MinFreshMetricsRatio: 1},
+			pods:        []*datastore.PodMetrics{pod("pod1", time.Hour, false), pod("pod2", -1, false)},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "1 of 2 ready pods have fresh metrics, at least 100% required",
+		},
+		{
+			name:        "No requirements",
+			pods:        nil,
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "0 ready pods, 0 with fresh metrics",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.config.evaluate(test.pods, now)
+			wantReason := v1alpha1.PoolReasonReady
+			if test.wantStatus != metav1.ConditionTrue {
+				wantReason = v1alpha1.PoolReasonEPPNotHealthy
+			}
+			want := metav1.Condition{
+				Type:    string(v1alpha1.PoolConditionReady),
+				Status:  test.wantStatus,
+				Reason:  string(wantReason),
+				Message: test.wantMessage,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Unexpected condition (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateStatus_InferencePoolReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+
+	pool := &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Generation: 1},
+		Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool).
+		WithStatusSubresource(&v1alpha1.InferencePool{}).
+		Build()
+	// The datastore holds the pool as read from the client, like the reconciler does.
+	syncPool := func(ds datastore.Datastore) {
+		got := &v1alpha1.InferencePool{}
+		if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "pool", Namespace: "default"}, got); err != nil {
+			t.Fatalf("Unexpected InferencePool get error: %v", err)
+		}
+		ds.PoolSet(got)
+	}
+	ds := datastore.NewDatastore()
+	syncPool(ds)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &InferencePoolReconciler{
+		Client:     fakeClient,
+		Record:     recorder,
+		Pools:      datastore.Pools{{Name: "pool", Namespace: "default"}: ds},
+		PoolStatus: PoolStatusConfig{MinReadyPods: 1},
+	}
+	update := func(step string) {
+		t.Helper()
+		if err := reconciler.updateStatus(context.Background(), ds); err != nil {
+			t.Fatalf("%s: unexpected updateStatus error: %v", step, err)
+		}
+		syncPool(ds)
+	}
+	checkStatus := func(step string, want metav1.ConditionStatus, wantReason v1alpha1.InferencePoolConditionReason) {
+		t.Helper()
+		got, _ := ds.PoolGet()
+		wantConditions := []metav1.Condition{{
+			Type:               string(v1alpha1.PoolConditionReady),
+			Status:             want,
+			Reason:             string(wantReason),
+			ObservedGeneration: 1,
+		}}
+		if diff := cmp.Diff(wantConditions, got.Status.Conditions,
+			cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime")); diff != "" {
+			t.Errorf("%s: unexpected conditions (-want +got): %s", step, diff)
+		}
+	}
+	checkEvents := func(step string, want ...string) {
+		t.Helper()
+		var got []string
+		for len(recorder.Events) > 0 {
+			got = append(got, <-recorder.Events)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s: unexpected events (-want +got): %s", step, diff)
+		}
+	}
+
+	update("no pods")
+	checkStatus("no pods", metav1.ConditionFalse, v1alpha1.PoolReasonEPPNotHealthy)
+	checkEvents("no pods", "Warning EndpointPickerNotHealthy 0 ready pods, at least 1 required")
+
+	// Unchanged conditions are neither patched nor recorded.
+	update("unchanged")
+	checkEvents("unchanged")
+
+	ds.PodUpdateOrAddEndpointIfNotExist(types.NamespacedName{Name: "pod1", Namespace: "default"}, "10.0.0.1", false)
+	update("pod added")
+	checkStatus("pod added", metav1.ConditionTrue, v1alpha1.PoolReasonReady)
+	checkEvents("pod added", "Normal Ready 1 ready pods, 0 with fresh metrics")
+
+	got, _ := ds.PoolGet()
+	if ready := meta.FindStatusCondition(got.Status.Conditions, string(v1alpha1.PoolConditionReady)); ready.LastTransitionTime.IsZero() {
+		t.Errorf("Expected the Ready condition to have a transition time")
+	}
+}
+
+func TestUpdateStatus_PoolNotSynced(t *testing.T) {
+	reconciler := &InferencePoolReconciler{PoolStatus: PoolStatusConfig{MinReadyPods: 1}}
+	// The client is not used, since there is no pool to patch.
+	if err := reconciler.updateStatus(context.Background(), datastore.NewDatastore()); err != nil {
+		t.Errorf("Unexpected updateStatus error for a pool that has not synced: %v", err)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
@@ -34,19 +35,25 @@ import (
 
 type PodReconciler struct {
 	client.Client
-	Datastore datastore.Datastore
-	Scheme    *runtime.Scheme
-	Record    record.EventRecorder
+	// Pools are the datastores of the InferencePools served by the EPP. A pod is added to the
+	// datastore of every pool in its namespace whose selector it matches.
+	Pools  datastore.Pools
+	Scheme *runtime.Scheme
+	Record record.EventRecorder
 }
 
 func (c *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
-	inferencePool, err := c.Datastore.PoolGet()
-	if err != nil {
-		logger.V(logutil.TRACE).Info("Skipping reconciling Pod because the InferencePool is not available yet", "error", err)
-		// When the inferencePool is initialized it lists the appropriate pods and populates the datastore, so no need to requeue.
-		return ctrl.Result{}, nil
-	} else if inferencePool.Namespace != req.Namespace {
+	pools := map[types.NamespacedName]datastore.Datastore{}
+	for name, ds := range c.Pools.InNamespace(req.Namespace) {
+		if !ds.PoolHasSynced() {
+			// When the inferencePool is initialized it lists the appropriate pods and populates the datastore, so no need to requeue.
+			logger.V(logutil.TRACE).Info("Skipping InferencePool for reconciling Pod because it is not available yet", "pool", name)
+			continue
+		}
+		pools[name] = ds
+	}
+	if len(pools) == 0 {
 		return ctrl.Result{}, nil
 	}
 
@@ -55,30 +62,48 @@ func (c *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	pod := &corev1.Pod{}
 	if err := c.Get(ctx, req.NamespacedName, pod); err != nil {
 		if apierrors.IsNotFound(err) {
-			c.Datastore.PodDelete(req.NamespacedName)
+			for name, ds := range pools {
+				if _, ok := ds.PodGet(req.NamespacedName); ok {
+					logger.V(logutil.DEFAULT).Info("Pod removed", "name", req.NamespacedName, "pool", name,
+						"inFlightRequests", ds.PodInFlightRequests(req.NamespacedName))
+				}
+				ds.PodDelete(req.NamespacedName)
+			}
 			return ctrl.Result{}, nil
 		}
 		logger.V(logutil.DEFAULT).Error(err, "Unable to get pod", "name", req.NamespacedName)
 		return ctrl.Result{}, err
 	}
 
-	c.updateDatastore(logger, pod)
+	for name, ds := range pools {
+		c.updateDatastore(logger.WithValues("pool", name), ds, pod)
+	}
 	return ctrl.Result{}, nil
 }
 
 func (c *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Pod{}).
+		For(&corev1.Pod{}, builder.WithPredicates(podChanged())).
+		WithOptions(syncOptions()).
 		Complete(c)
 }
 
-func (c *PodReconciler) updateDatastore(logger logr.Logger, pod *corev1.Pod) {
+func (c *PodReconciler) updateDatastore(logger logr.Logger, ds datastore.Datastore, pod *corev1.Pod) {
 	namespacedName := types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}
-	if !pod.DeletionTimestamp.IsZero() || !c.Datastore.PoolLabelsMatch(pod.Labels) || !podIsReady(pod) {
+	existing, exists := ds.PodGet(namespacedName)
+	if exists && ds.PoolLabelsMatch(pod.Labels) && datastore.PodIsDraining(pod) {
+		// Draining pods are kept until they are deleted, so that their in-flight requests are tracked,
+		// but they don't get new requests.
+		ds.PodUpdateOrAddIfNotExist(pod)
+		if !existing.Draining {
+			logger.V(logutil.DEFAULT).Info("Pod draining", "name", namespacedName,
+				"terminating", !pod.DeletionTimestamp.IsZero(), "inFlightRequests", ds.PodInFlightRequests(namespacedName))
+		}
+	} else if datastore.PodIsDraining(pod) || !ds.PoolLabelsMatch(pod.Labels) || !podIsReady(pod) {
 		logger.V(logutil.DEFAULT).Info("Pod removed or not added", "name", namespacedName)
-		c.Datastore.PodDelete(namespacedName)
+		ds.PodDelete(namespacedName)
 	} else {
-		if c.Datastore.PodUpdateOrAddIfNotExist(pod) {
+		if ds.PodUpdateOrAddIfNotExist(pod) {
 			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName)
 		} else {
 			logger.V(logutil.DEFAULT).Info("Pod already exists", "name", namespacedName)
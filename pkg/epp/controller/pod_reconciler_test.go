@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	utiltesting "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
 )
 
 var (
@@ -40,6 +41,8 @@ var (
 	basePod2  = &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}, Address: "address-2", ScrapePath: "/metrics", ScrapePort: 8000}}
 	basePod3  = &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod3"}, Address: "address-3", ScrapePath: "/metrics", ScrapePort: 8000}}
 	basePod11 = &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}, Address: "address-11", ScrapePath: "/metrics", ScrapePort: 8000}}
+
+	drainingPod1 = datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}, Address: "address-1", ScrapePath: "/metrics", ScrapePort: 8000, Draining: true}
 )
 
 func TestUpdateDatastore_PodReconciler(t *testing.T) {
@@ -110,7 +113,40 @@ func TestUpdateDatastore_PodReconciler(t *testing.T) {
 			wantPods: []datastore.Pod{basePod11.Pod, basePod2.Pod},
 		},
 		{
-			name: "Delete pod with DeletionTimestamp",
+			name: "Delete canary pod excluded by labelSelector",
+			datastore: datastore.NewFakeDatastore(populateMap(basePod1, basePod2), nil, &v1alpha1.InferencePool{
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: int32(8000),
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"some-key": "some-val"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+						},
+					},
+				},
+			}),
+			incomingPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod1",
+					Labels: map[string]string{
+						"some-key": "some-val",
+						"track":    "canary",
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: basePod1.Address,
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			wantPods: []datastore.Pod{basePod2.Pod},
+		},
+		{
+			name: "Drain pod with DeletionTimestamp",
 			datastore: datastore.NewFakeDatastore(populateMap(basePod1, basePod2), nil, &v1alpha1.InferencePool{
 				Spec: v1alpha1.InferencePoolSpec{
 					TargetPortNumber: int32(8000),
@@ -129,6 +165,39 @@ func TestUpdateDatastore_PodReconciler(t *testing.T) {
 					Finalizers:        []string{"finalizer"},
 				},
 				Status: corev1.PodStatus{
+					PodIP: basePod1.Address,
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+			wantPods: []datastore.Pod{drainingPod1, basePod2.Pod},
+		},
+		{
+			name: "Drain pod with drain annotation",
+			datastore: datastore.NewFakeDatastore(populateMap(basePod1, basePod2), nil, &v1alpha1.InferencePool{
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: int32(8000),
+					Selector: map[v1alpha1.LabelKey]v1alpha1.LabelValue{
+						"some-key": "some-val",
+					},
+				},
+			}),
+			incomingPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod1",
+					Labels: map[string]string{
+						"some-key": "some-val",
+					},
+					Annotations: map[string]string{
+						datastore.DrainAnnotation: "true",
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: basePod1.Address,
 					Conditions: []corev1.PodCondition{
 						{
 							Type:   corev1.PodReady,
@@ -137,7 +206,39 @@ func TestUpdateDatastore_PodReconciler(t *testing.T) {
 					},
 				},
 			},
-			wantPods: []datastore.Pod{basePod2.Pod},
+			wantPods: []datastore.Pod{drainingPod1, basePod2.Pod},
+		},
+		{
+			name: "Do not add draining pod",
+			datastore: datastore.NewFakeDatastore(populateMap(basePod1, basePod2), nil, &v1alpha1.InferencePool{
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: int32(8000),
+					Selector: map[v1alpha1.LabelKey]v1alpha1.LabelValue{
+						"some-key": "some-val",
+					},
+				},
+			}),
+			incomingPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: basePod3.NamespacedName.Name,
+					Labels: map[string]string{
+						"some-key": "some-val",
+					},
+					Annotations: map[string]string{
+						datastore.DrainAnnotation: "true",
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: basePod3.Address,
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			wantPods: []datastore.Pod{basePod1.Pod, basePod2.Pod},
 		},
 		{
 			name: "Delete notfound pod",
@@ -251,9 +352,13 @@ func TestUpdateDatastore_PodReconciler(t *testing.T) {
 				WithObjects(initialObjects...).
 				Build()
 
-			podReconciler := &PodReconciler{Client: fakeClient, Datastore: test.datastore}
-			namespacedName := types.NamespacedName{Name: test.incomingPod.Name, Namespace: test.incomingPod.Namespace}
+			pool, _ := test.datastore.PoolGet()
+			podReconciler := &PodReconciler{
+				Client: fakeClient,
+				Pools:  datastore.Pools{types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}: test.datastore},
+			}
 			if test.req == nil {
+				namespacedName := types.NamespacedName{Name: test.incomingPod.Name, Namespace: test.incomingPod.Namespace}
 				test.req = &ctrl.Request{NamespacedName: namespacedName}
 			}
 			if _, err := podReconciler.Reconcile(context.Background(), *test.req); err != nil {
@@ -275,6 +380,70 @@ func TestUpdateDatastore_PodReconciler(t *testing.T) {
 	}
 }
 
+func TestReconcile_PodReconcilerMultiplePools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPool := func(name string, selector map[v1alpha1.LabelKey]v1alpha1.LabelValue) datastore.Datastore {
+		return datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000, Selector: selector},
+		})
+	}
+	llama := newPool("llama", map[v1alpha1.LabelKey]v1alpha1.LabelValue{"model": "llama"})
+	all := newPool("all", map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"})
+	other := datastore.NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "other"},
+		Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000, Selector: map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"}},
+	})
+	pools := datastore.Pools{
+		{Name: "llama", Namespace: "default"}: llama,
+		{Name: "all", Namespace: "default"}:   all,
+		{Name: "all", Namespace: "other"}:     other,
+	}
+
+	llamaPod := utiltesting.MakePod("llama-pod", "default").Labels(map[string]string{"app": "vllm", "model": "llama"}).ReadyCondition().Obj()
+	mistralPod := utiltesting.MakePod("mistral-pod", "default").Labels(map[string]string{"app": "vllm", "model": "mistral"}).ReadyCondition().Obj()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&llamaPod, &mistralPod).Build()
+	reconciler := &PodReconciler{Client: fakeClient, Pools: pools}
+
+	reconcile := func(pod corev1.Pod) {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Errorf("Unexpected pod reconcile error: %v", err)
+		}
+	}
+	podNames := func(ds datastore.Datastore) []string {
+		names := []string{}
+		for _, pm := range ds.PodGetAll() {
+			names = append(names, pm.NamespacedName.Name)
+		}
+		return names
+	}
+	check := func(step string, wantLlama, wantAll []string) {
+		sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+		if diff := cmp.Diff(wantLlama, podNames(llama), sortStrings); diff != "" {
+			t.Errorf("%s: unexpected pods in pool llama (-want +got): %s", step, diff)
+		}
+		if diff := cmp.Diff(wantAll, podNames(all), sortStrings); diff != "" {
+			t.Errorf("%s: unexpected pods in pool all (-want +got): %s", step, diff)
+		}
+		if got := podNames(other); len(got) != 0 {
+			t.Errorf("%s: expected no pods in a pool of another namespace, got %v", step, got)
+		}
+	}
+
+	reconcile(llamaPod)
+	reconcile(mistralPod)
+	check("pods added", []string{"llama-pod"}, []string{"llama-pod", "mistral-pod"})
+
+	if err := fakeClient.Delete(context.Background(), &llamaPod); err != nil {
+		t.Fatalf("Unexpected pod delete error: %v", err)
+	}
+	reconcile(llamaPod)
+	check("pod deleted", []string{}, []string{"mistral-pod"})
+}
+
 func populateMap(pods ...*datastore.PodMetrics) *sync.Map {
 	newMap := &sync.Map{}
 	for _, pod := range pods {
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+// specOrAnnotationChanged drops the updates of InferencePools that only change their status or
+// other metadata, e.g. the status written by the leader. Annotations configure how the pods of a
+// pool are scraped and discovered.
+func specOrAnnotationChanged() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+}
+
+// podChanged drops the updates of pods that don't change any field used by the datastore, most
+// notably status churn such as container restarts or probe results not affecting readiness.
+func podChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return true
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return true
+			}
+			return oldPod.Status.PodIP != newPod.Status.PodIP ||
+				podIsReady(oldPod) != podIsReady(newPod) ||
+				datastore.PodIsDraining(oldPod) != datastore.PodIsDraining(newPod) ||
+				!equality.Semantic.DeepEqual(oldPod.Labels, newPod.Labels)
+		},
+	}
+}
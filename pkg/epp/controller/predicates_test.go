@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	utiltesting "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+func TestPodChanged(t *testing.T) {
+	base := utiltesting.MakePod("pod1", "default").Labels(selector_v1).ReadyCondition().IP("10.0.0.1").Obj()
+	tests := []struct {
+		name   string
+		modify func(pod *corev1.Pod)
+		want   bool
+	}{
+		{
+			name: "Container restart",
+			modify: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "vllm", RestartCount: 1}}
+			},
+			want: false,
+		},
+		{
+			name:   "Unrelated annotation",
+			modify: func(pod *corev1.Pod) { pod.Annotations = map[string]string{"foo": "bar"} },
+			want:   false,
+		},
+		{
+			name: "Not ready",
+			modify: func(pod *corev1.Pod) {
+				pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+			},
+			want: true,
+		},
+		{
+			name:   "IP changed",
+			modify: func(pod *corev1.Pod) { pod.Status.PodIP = "10.0.0.2" },
+			want:   true,
+		},
+		{
+			name:   "Labels changed",
+			modify: func(pod *corev1.Pod) { pod.Labels = selector_v2 },
+			want:   true,
+		},
+		{
+			name:   "Drain annotation",
+			modify: func(pod *corev1.Pod) { pod.Annotations = map[string]string{datastore.DrainAnnotation: "true"} },
+			want:   true,
+		},
+		{
+			name:   "Terminating",
+			modify: func(pod *corev1.Pod) { now := metav1.Now(); pod.DeletionTimestamp = &now },
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newPod := base.DeepCopy()
+			test.modify(newPod)
+			if got := podChanged().Update(event.UpdateEvent{ObjectOld: &base, ObjectNew: newPod}); got != test.want {
+				t.Errorf("Expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -19,11 +19,14 @@ package datastore
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,7 +35,21 @@ import (
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
-// The datastore is a local cache of relevant data for the given InferencePool (currently all pulled from k8s-api)
+// MetricsSchemeAnnotation can be set on an InferencePool to select the URL scheme ("http" or
+// "https") used to scrape the metrics of the pool's model servers.
+const MetricsSchemeAnnotation = "inference.networking.x-k8s.io/metrics-scheme"
+
+// DrainAnnotation can be set to "true" on a model server pod to stop routing new requests to it,
+// e.g. before it is upgraded. Terminating pods are drained as well.
+const DrainAnnotation = "inference.networking.x-k8s.io/drain"
+
+// ServiceAnnotation can be set on an InferencePool to name the Service whose EndpointSlices are
+// used to discover the pool's model server pods, if endpoints are discovered from EndpointSlices.
+// It defaults to the name of the pool.
+const ServiceAnnotation = "inference.networking.x-k8s.io/service"
+
+// The datastore is a local cache of relevant data for the given InferencePool (currently all pulled from k8s-api).
+// An EPP serving multiple InferencePools has one datastore per pool, see Pools.
 type Datastore interface {
 	// InferencePool operations
 	PoolSet(pool *v1alpha1.InferencePool)
@@ -43,17 +60,29 @@ type Datastore interface {
 	// InferenceModel operations
 	ModelSet(infModel *v1alpha1.InferenceModel)
 	ModelGet(modelName string) (*v1alpha1.InferenceModel, bool)
+	ModelGetAll() []*v1alpha1.InferenceModel
 	ModelDelete(modelName string)
+	ModelDeleteByNamespacedName(namespacedName types.NamespacedName) bool
 
 	// PodMetrics operations
 	PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool
+	PodUpdateOrAddEndpointIfNotExist(namespacedName types.NamespacedName, address string, draining bool) bool
 	PodUpdateMetricsIfExist(namespacedName types.NamespacedName, m *Metrics) bool
+	PodUpdateServedModelsIfExist(namespacedName types.NamespacedName, sm *ServedModels) bool
 	PodGet(namespacedName types.NamespacedName) (*PodMetrics, bool)
 	PodDelete(namespacedName types.NamespacedName)
 	PodResyncAll(ctx context.Context, ctrlClient client.Client)
 	PodGetAll() []*PodMetrics
+	PodSnapshot() *PodSnapshot
 	PodDeleteAll() // This is only for testing.
 	PodRange(f func(key, value any) bool)
+	// PodTrackRequest records a request in flight on the pod until the returned function is called.
+	PodTrackRequest(namespacedName types.NamespacedName) (done func())
+	PodInFlightRequests(namespacedName types.NamespacedName) int
+
+	// Subscribe returns a subscription to the changes of the store, see Event. A bufferSize <= 0
+	// uses DefaultSubscriptionBufferSize.
+	Subscribe(bufferSize int) *Subscription
 
 	// Clears the store state, happens when the pool gets deleted.
 	Clear()
@@ -89,15 +118,46 @@ type datastore struct {
 	pool   *v1alpha1.InferencePool
 	models *sync.Map
 	// key: types.NamespacedName, value: *PodMetrics
+	// The stored PodMetrics are never modified in place, every update stores a modified copy, so
+	// that readers always observe a consistent view of a pod.
 	pods *sync.Map
+	// podsMu serializes pod updates, and snapshot builds with respect to pod updates.
+	podsMu sync.Mutex
+	// podsGeneration is incremented on every pod update.
+	podsGeneration atomic.Uint64
+	// snapshot is the latest built snapshot of the pods, it is rebuilt lazily once it is outdated.
+	snapshot atomic.Pointer[PodSnapshot]
+	// subscribers are notified of every change. Pod events are published while holding podsMu, so
+	// that they are delivered in generation order.
+	subscribers subscribers
+	// inFlight counts the requests in flight per pod.
+	// key: types.NamespacedName, value: *atomic.Int64
+	// Counters outlive the removal of a pod until its last request completes.
+	inFlight sync.Map
 }
 
+// PodSnapshot is an immutable, consistent view of all pods and their metrics.
+// Neither the slice nor the pods it points to must be modified.
+type PodSnapshot struct {
+	// Generation identifies the state of the pods the snapshot was built from.
+	Generation uint64
+	Pods       []*PodMetrics
+}
+
+// Clear removes the pool, all models and all pods, and emits a single EventPoolCleared.
 func (ds *datastore) Clear() {
 	ds.poolMu.Lock()
 	defer ds.poolMu.Unlock()
 	ds.pool = nil
 	ds.models.Clear()
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
 	ds.pods.Clear()
+	ds.subscribers.publish(Event{Type: EventPoolCleared, PodGeneration: ds.podsGeneration.Add(1)})
+}
+
+func (ds *datastore) Subscribe(bufferSize int) *Subscription {
+	return ds.subscribers.subscribe(bufferSize)
 }
 
 // /// InferencePool APIs ///
@@ -105,6 +165,7 @@ func (ds *datastore) PoolSet(pool *v1alpha1.InferencePool) {
 	ds.poolMu.Lock()
 	defer ds.poolMu.Unlock()
 	ds.pool = pool
+	ds.subscribers.publish(Event{Type: EventPoolSet, Pool: pool})
 }
 
 func (ds *datastore) PoolGet() (*v1alpha1.InferencePool, error) {
@@ -122,8 +183,15 @@ func (ds *datastore) PoolHasSynced() bool {
 	return ds.pool != nil
 }
 
+// PoolLabelsMatch returns true if the pod labels match the pool selector. It returns false if the
+// pool selector is invalid.
 func (ds *datastore) PoolLabelsMatch(podLabels map[string]string) bool {
-	poolSelector := selectorFromInferencePoolSelector(ds.pool.Spec.Selector)
+	ds.poolMu.RLock()
+	defer ds.poolMu.RUnlock()
+	poolSelector, err := PoolSelector(ds.pool)
+	if err != nil {
+		return false
+	}
 	podSet := labels.Set(podLabels)
 	return poolSelector.Matches(podSet)
 }
@@ -131,6 +199,7 @@ func (ds *datastore) PoolLabelsMatch(podLabels map[string]string) bool {
 // /// InferenceModel APIs ///
 func (ds *datastore) ModelSet(infModel *v1alpha1.InferenceModel) {
 	ds.models.Store(infModel.Spec.ModelName, infModel)
+	ds.subscribers.publish(Event{Type: EventModelSet, Model: infModel})
 }
 
 func (ds *datastore) ModelGet(modelName string) (*v1alpha1.InferenceModel, bool) {
@@ -141,18 +210,64 @@ func (ds *datastore) ModelGet(modelName string) (*v1alpha1.InferenceModel, bool)
 	return nil, false
 }
 
+func (ds *datastore) ModelGetAll() []*v1alpha1.InferenceModel {
+	res := []*v1alpha1.InferenceModel{}
+	ds.models.Range(func(k, v any) bool {
+		res = append(res, v.(*v1alpha1.InferenceModel))
+		return true
+	})
+	return res
+}
+
 func (ds *datastore) ModelDelete(modelName string) {
-	ds.models.Delete(modelName)
+	if infModel, ok := ds.models.LoadAndDelete(modelName); ok {
+		ds.subscribers.publish(Event{Type: EventModelDeleted, Model: infModel.(*v1alpha1.InferenceModel)})
+	}
+}
+
+// ModelDeleteByNamespacedName deletes the model stored from the InferenceModel with the given
+// namespaced name, if any. It is used when the model name of the object is not known, e.g. because
+// the object was deleted, or when another object may have registered the same model name.
+func (ds *datastore) ModelDeleteByNamespacedName(namespacedName types.NamespacedName) bool {
+	deleted := false
+	ds.models.Range(func(k, v any) bool {
+		infModel := v.(*v1alpha1.InferenceModel)
+		if infModel.Name == namespacedName.Name && infModel.Namespace == namespacedName.Namespace && ds.models.CompareAndDelete(k, v) {
+			ds.subscribers.publish(Event{Type: EventModelDeleted, Model: infModel})
+			deleted = true
+		}
+		return true
+	})
+	return deleted
 }
 
 // /// Pods/endpoints APIs ///
 func (ds *datastore) PodUpdateMetricsIfExist(namespacedName types.NamespacedName, m *Metrics) bool {
-	if val, ok := ds.pods.Load(namespacedName); ok {
-		existing := val.(*PodMetrics)
-		existing.Metrics = *m
-		return true
+	return ds.podUpdateIfExist(namespacedName, EventPodMetricsRefreshed, func(pm *PodMetrics) {
+		pm.Metrics = *m
+	})
+}
+
+func (ds *datastore) PodUpdateServedModelsIfExist(namespacedName types.NamespacedName, sm *ServedModels) bool {
+	return ds.podUpdateIfExist(namespacedName, EventPodUpdated, func(pm *PodMetrics) {
+		pm.ServedModels = *sm
+	})
+}
+
+// podUpdateIfExist stores a copy of the pod modified by the given function.
+// The copy is shallow, the modify function must replace rather than mutate reference fields.
+func (ds *datastore) podUpdateIfExist(namespacedName types.NamespacedName, eventType EventType, modify func(pm *PodMetrics)) bool {
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
+	val, ok := ds.pods.Load(namespacedName)
+	if !ok {
+		return false
 	}
-	return false
+	updated := *val.(*PodMetrics)
+	modify(&updated)
+	ds.pods.Store(namespacedName, &updated)
+	ds.subscribers.publish(Event{Type: eventType, Pod: &updated, PodGeneration: ds.podsGeneration.Add(1)})
+	return true
 }
 
 func (ds *datastore) PodGet(namespacedName types.NamespacedName) (*PodMetrics, bool) {
@@ -164,56 +279,105 @@ func (ds *datastore) PodGet(namespacedName types.NamespacedName) (*PodMetrics, b
 }
 
 func (ds *datastore) PodGetAll() []*PodMetrics {
-	res := []*PodMetrics{}
-	fn := func(k, v any) bool {
-		res = append(res, v.(*PodMetrics))
-		return true
-	}
-	ds.pods.Range(fn)
+	pods := ds.PodSnapshot().Pods
+	res := make([]*PodMetrics, len(pods))
+	copy(res, pods)
 	return res
 }
 
+// PodSnapshot returns a consistent view of all pods. Snapshots are shared between callers until
+// the pods are updated.
+func (ds *datastore) PodSnapshot() *PodSnapshot {
+	if snapshot := ds.snapshot.Load(); snapshot != nil && snapshot.Generation == ds.podsGeneration.Load() {
+		return snapshot
+	}
+
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
+	snapshot := &PodSnapshot{Generation: ds.podsGeneration.Load(), Pods: []*PodMetrics{}}
+	ds.pods.Range(func(k, v any) bool {
+		snapshot.Pods = append(snapshot.Pods, v.(*PodMetrics))
+		return true
+	})
+	ds.snapshot.Store(snapshot)
+	return snapshot
+}
+
 func (ds *datastore) PodRange(f func(key, value any) bool) {
 	ds.pods.Range(f)
 }
 
 func (ds *datastore) PodDelete(namespacedName types.NamespacedName) {
-	ds.pods.Delete(namespacedName)
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
+	if pm, ok := ds.pods.LoadAndDelete(namespacedName); ok {
+		ds.subscribers.publish(Event{Type: EventPodRemoved, Pod: pm.(*PodMetrics), PodGeneration: ds.podsGeneration.Add(1)})
+	}
+	if val, ok := ds.inFlight.Load(namespacedName); ok && val.(*atomic.Int64).Load() == 0 {
+		ds.inFlight.CompareAndDelete(namespacedName, val)
+	}
 }
 
 func (ds *datastore) PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool {
+	namespacedName := types.NamespacedName{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+	}
+	return ds.PodUpdateOrAddEndpointIfNotExist(namespacedName, pod.Status.PodIP, PodIsDraining(pod))
+}
+
+// PodUpdateOrAddEndpointIfNotExist is like PodUpdateOrAddIfNotExist for a pod discovered from an
+// endpoint rather than a Pod object.
+func (ds *datastore) PodUpdateOrAddEndpointIfNotExist(namespacedName types.NamespacedName, address string, draining bool) bool {
 	pool, _ := ds.PoolGet()
+	// Pools with an invalid scheme are rejected by ValidateInferencePool, the default scheme is
+	// used if one gets here anyway.
+	scheme, _ := PoolMetricsScheme(pool)
 	new := &PodMetrics{
 		Pod: Pod{
-			NamespacedName: types.NamespacedName{
-				Name:      pod.Name,
-				Namespace: pod.Namespace,
-			},
-			Address:    pod.Status.PodIP,
-			ScrapePath: "/metrics",
-			ScrapePort: pool.Spec.TargetPortNumber,
+			NamespacedName: namespacedName,
+			Address:        address,
+			ScrapePath:     "/metrics",
+			ScrapePort:     pool.Spec.TargetPortNumber,
+			ScrapeScheme:   scheme,
+			Draining:       draining,
 		},
 		Metrics: Metrics{
 			ActiveModels: make(map[string]int),
 		},
 	}
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
 	existing, ok := ds.pods.Load(new.NamespacedName)
 	if !ok {
 		ds.pods.Store(new.NamespacedName, new)
+		ds.subscribers.publish(Event{Type: EventPodAdded, Pod: new, PodGeneration: ds.podsGeneration.Add(1)})
 		return true
 	}
 
 	// Update pod properties if anything changed.
-	existing.(*PodMetrics).Pod = new.Pod
+	if existing.(*PodMetrics).Pod != new.Pod {
+		updated := *existing.(*PodMetrics)
+		updated.Pod = new.Pod
+		ds.pods.Store(new.NamespacedName, &updated)
+		ds.subscribers.publish(Event{Type: EventPodUpdated, Pod: &updated, PodGeneration: ds.podsGeneration.Add(1)})
+	}
 	return false
 }
 
+// PodResyncAll adds, updates and removes pods to match the pods selected by the pool. Each change
+// emits the same event as the individual pod operations.
 func (ds *datastore) PodResyncAll(ctx context.Context, ctrlClient client.Client) {
 	// Pool must exist to invoke this function.
 	pool, _ := ds.PoolGet()
+	selector, err := PoolSelector(pool)
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Invalid InferencePool selector")
+		return
+	}
 	podList := &corev1.PodList{}
 	if err := ctrlClient.List(ctx, podList, &client.ListOptions{
-		LabelSelector: selectorFromInferencePoolSelector(pool.Spec.Selector),
+		LabelSelector: selector,
 		Namespace:     pool.Namespace,
 	}); err != nil {
 		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Failed to list clients")
@@ -222,7 +386,9 @@ func (ds *datastore) PodResyncAll(ctx context.Context, ctrlClient client.Client)
 
 	activePods := make(map[string]bool)
 	for _, pod := range podList.Items {
-		if podIsReady(&pod) {
+		_, exists := ds.PodGet(types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace})
+		// Draining pods are kept, but not added.
+		if (podIsReady(&pod) && !PodIsDraining(&pod)) || (exists && PodIsDraining(&pod)) {
 			activePods[pod.Name] = true
 			ds.PodUpdateOrAddIfNotExist(&pod)
 		}
@@ -232,19 +398,73 @@ func (ds *datastore) PodResyncAll(ctx context.Context, ctrlClient client.Client)
 	deleteFn := func(k, v any) bool {
 		pm := v.(*PodMetrics)
 		if exist := activePods[pm.NamespacedName.Name]; !exist {
-			ds.pods.Delete(pm.NamespacedName)
+			ds.PodDelete(pm.NamespacedName)
 		}
 		return true
 	}
 	ds.pods.Range(deleteFn)
 }
 
+func (ds *datastore) PodTrackRequest(namespacedName types.NamespacedName) func() {
+	val, _ := ds.inFlight.LoadOrStore(namespacedName, &atomic.Int64{})
+	count := val.(*atomic.Int64)
+	count.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if count.Add(-1) > 0 {
+				return
+			}
+			if _, ok := ds.pods.Load(namespacedName); !ok {
+				// The pod was removed and its last request completed.
+				ds.inFlight.CompareAndDelete(namespacedName, count)
+			}
+		})
+	}
+}
+
+func (ds *datastore) PodInFlightRequests(namespacedName types.NamespacedName) int {
+	if val, ok := ds.inFlight.Load(namespacedName); ok {
+		return int(val.(*atomic.Int64).Load())
+	}
+	return 0
+}
+
+// PodDeleteAll removes all pods, emitting an EventPodRemoved for each of them.
 func (ds *datastore) PodDeleteAll() {
-	ds.pods.Clear()
+	ds.podsMu.Lock()
+	defer ds.podsMu.Unlock()
+	ds.pods.Range(func(k, v any) bool {
+		ds.pods.Delete(k)
+		ds.subscribers.publish(Event{Type: EventPodRemoved, Pod: v.(*PodMetrics), PodGeneration: ds.podsGeneration.Add(1)})
+		return true
+	})
 }
 
-func selectorFromInferencePoolSelector(selector map[v1alpha1.LabelKey]v1alpha1.LabelValue) labels.Selector {
-	return labels.SelectorFromSet(stripLabelKeyAliasFromLabelMap(selector))
+// PoolSelector returns the selector of the model server pods of the pool, built from either the
+// Selector or the LabelSelector field. Exactly one of them must be set, and an empty selector is
+// rejected rather than selecting every pod in the namespace.
+func PoolSelector(pool *v1alpha1.InferencePool) (labels.Selector, error) {
+	if pool == nil {
+		return nil, errors.New("InferencePool is not initialized in data store")
+	}
+	if pool.Spec.LabelSelector != nil {
+		if len(pool.Spec.Selector) > 0 {
+			return nil, fmt.Errorf("InferencePool %s/%s sets both selector and labelSelector", pool.Namespace, pool.Name)
+		}
+		if len(pool.Spec.LabelSelector.MatchLabels) == 0 && len(pool.Spec.LabelSelector.MatchExpressions) == 0 {
+			return nil, fmt.Errorf("InferencePool %s/%s sets an empty labelSelector", pool.Namespace, pool.Name)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pool.Spec.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector of InferencePool %s/%s: %w", pool.Namespace, pool.Name, err)
+		}
+		return selector, nil
+	}
+	if len(pool.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("InferencePool %s/%s sets neither selector nor labelSelector", pool.Namespace, pool.Name)
+	}
+	return labels.SelectorFromSet(stripLabelKeyAliasFromLabelMap(pool.Spec.Selector)), nil
 }
 
 func stripLabelKeyAliasFromLabelMap(labels map[v1alpha1.LabelKey]v1alpha1.LabelValue) map[string]string {
@@ -255,6 +475,9 @@ func stripLabelKeyAliasFromLabelMap(labels map[v1alpha1.LabelKey]v1alpha1.LabelV
 	return outMap
 }
 
+// RandomWeightedDraw returns the name of a target model of the InferenceModel, drawn in proportion
+// to the weights of the target models. Target models are drawn uniformly if no weights are set. It
+// returns "" if the total weight is not positive, which ValidateInferenceModel rejects.
 func RandomWeightedDraw(logger logr.Logger, model *v1alpha1.InferenceModel, seed int64) string {
 	var weights int32
 
@@ -264,19 +487,31 @@ func RandomWeightedDraw(logger logr.Logger, model *v1alpha1.InferenceModel, seed
 	}
 	r := rand.New(source)
 	for _, model := range model.Spec.TargetModels {
-		weights += *model.Weight
+		weights += TargetModelWeight(model)
 	}
 	logger.V(logutil.TRACE).Info("Weights for model computed", "model", model.Name, "weights", weights)
+	if weights <= 0 {
+		return ""
+	}
 	randomVal := r.Int31n(weights)
 	for _, model := range model.Spec.TargetModels {
-		if randomVal < *model.Weight {
+		if randomVal < TargetModelWeight(model) {
 			return model.Name
 		}
-		randomVal -= *model.Weight
+		randomVal -= TargetModelWeight(model)
 	}
 	return ""
 }
 
+// TargetModelWeight returns the weight of the target model, 1 if it is not set. Negative weights
+// are ignored.
+func TargetModelWeight(tm v1alpha1.TargetModel) int32 {
+	if tm.Weight == nil {
+		return 1
+	}
+	return max(*tm.Weight, 0)
+}
+
 func IsCritical(model *v1alpha1.InferenceModel) bool {
 	if model.Spec.Criticality != nil && *model.Spec.Criticality == v1alpha1.Critical {
 		return true
@@ -284,6 +519,30 @@ func IsCritical(model *v1alpha1.InferenceModel) bool {
 	return false
 }
 
+// PoolMetricsScheme returns the metrics scheme set on the pool with MetricsSchemeAnnotation, or ""
+// if the annotation is not set. It returns an error if the annotation is neither "http" nor
+// "https".
+func PoolMetricsScheme(pool *v1alpha1.InferencePool) (string, error) {
+	scheme, ok := pool.Annotations[MetricsSchemeAnnotation]
+	if !ok || scheme == "http" || scheme == "https" {
+		return scheme, nil
+	}
+	return "", fmt.Errorf("unsupported metrics scheme %q, must be http or https", scheme)
+}
+
+// PoolServiceName returns the name of the Service backing the pool, see ServiceAnnotation.
+func PoolServiceName(pool *v1alpha1.InferencePool) string {
+	if name := pool.Annotations[ServiceAnnotation]; name != "" {
+		return name
+	}
+	return pool.Name
+}
+
+// PodIsDraining returns true if the pod is terminating or marked with the DrainAnnotation.
+func PodIsDraining(pod *corev1.Pod) bool {
+	return !pod.DeletionTimestamp.IsZero() || pod.Annotations[DrainAnnotation] == "true"
+}
+
 // TODO: move out to share with pod_reconciler.go
 func podIsReady(pod *corev1.Pod) bool {
 	for _, condition := range pod.Status.Conditions {
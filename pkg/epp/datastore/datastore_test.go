@@ -17,9 +17,15 @@ limitations under the License.
 package datastore
 
 import (
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -131,6 +137,41 @@ func TestRandomWeightedDraw(t *testing.T) {
 			},
 			want: "v1.1",
 		},
+		{
+			name: "unset weights count as one",
+			model: &v1alpha1.InferenceModel{
+				Spec: v1alpha1.InferenceModelSpec{
+					TargetModels: []v1alpha1.TargetModel{
+						{
+							Name:   "canary",
+							Weight: pointer(0),
+						},
+						{
+							Name: "v1",
+						},
+					},
+				},
+			},
+			want: "v1",
+		},
+		{
+			name: "zero total weight",
+			model: &v1alpha1.InferenceModel{
+				Spec: v1alpha1.InferenceModelSpec{
+					TargetModels: []v1alpha1.TargetModel{
+						{
+							Name:   "canary",
+							Weight: pointer(0),
+						},
+						{
+							Name:   "v1",
+							Weight: pointer(0),
+						},
+					},
+				},
+			},
+			want: "",
+		},
 	}
 	var seedVal int64 = 420
 	for _, test := range tests {
@@ -146,6 +187,358 @@ func TestRandomWeightedDraw(t *testing.T) {
 	}
 }
 
+func TestPodSnapshot(t *testing.T) {
+	ds := NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	})
+	pod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}}
+	name := types.NamespacedName{Name: "pod1", Namespace: "default"}
+
+	empty := ds.PodSnapshot()
+	if len(empty.Pods) != 0 {
+		t.Fatalf("Expected no pods, got %v", empty.Pods)
+	}
+	if got := ds.PodSnapshot(); got != empty {
+		t.Errorf("Expected the snapshot to be reused while the pods are unchanged")
+	}
+
+	ds.PodUpdateOrAddIfNotExist(pod)
+	added := ds.PodSnapshot()
+	if added.Generation <= empty.Generation || len(added.Pods) != 1 {
+		t.Fatalf("Expected a new snapshot with one pod, got %+v", added)
+	}
+	// Re-adding an unchanged pod does not invalidate the snapshot.
+	ds.PodUpdateOrAddIfNotExist(pod)
+	if got := ds.PodSnapshot(); got != added {
+		t.Errorf("Expected the snapshot to be reused after a no-op update")
+	}
+
+	ds.PodUpdateMetricsIfExist(name, &Metrics{WaitingQueueSize: 3})
+	updated := ds.PodSnapshot()
+	if updated.Generation <= added.Generation || updated.Pods[0].WaitingQueueSize != 3 {
+		t.Fatalf("Expected a new snapshot with updated metrics, got %+v", updated)
+	}
+	// Older snapshots are not modified by updates.
+	if added.Pods[0].WaitingQueueSize != 0 {
+		t.Errorf("Expected the previous snapshot to be unchanged, got %v", added.Pods[0])
+	}
+
+	ds.PodDelete(name)
+	if got := ds.PodSnapshot(); len(got.Pods) != 0 || got.Generation <= updated.Generation {
+		t.Errorf("Expected a new snapshot without pods, got %+v", got)
+	}
+}
+
+// TestPodSnapshotConcurrentUpdates is meant to be run with the race detector. Every update sets all
+// metrics of a pod to the same value, so that readers can detect torn updates.
+func TestPodSnapshotConcurrentUpdates(t *testing.T) {
+	ds := NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	})
+	var names []types.NamespacedName
+	for i := 0; i < 5; i++ {
+		pod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod" + strconv.Itoa(i), Namespace: "default"}}
+		ds.PodUpdateOrAddIfNotExist(pod)
+		names = append(names, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace})
+	}
+
+	checkConsistent := func(pm *PodMetrics) {
+		v := pm.WaitingQueueSize
+		if pm.RunningQueueSize != v || pm.KVCacheUsagePercent != float64(v) || len(pm.ActiveModels) != v {
+			t.Errorf("Inconsistent metrics observed: %v", pm)
+		}
+	}
+
+	done := make(chan struct{})
+	var writers, readers sync.WaitGroup
+	for _, name := range names {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for i := 0; i < 200; i++ {
+				activeModels := map[string]int{}
+				for j := 0; j < i; j++ {
+					activeModels[strconv.Itoa(j)] = 0
+				}
+				ds.PodUpdateMetricsIfExist(name, &Metrics{
+					RunningQueueSize:    i,
+					WaitingQueueSize:    i,
+					KVCacheUsagePercent: float64(i),
+					ActiveModels:        activeModels,
+					UpdateTime:          time.Now(),
+				})
+				ds.PodUpdateServedModelsIfExist(name, &ServedModels{
+					BaseModels: sets.New(strconv.Itoa(i)),
+					UpdateTime: time.Now(),
+				})
+			}
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			var generation uint64
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snapshot := ds.PodSnapshot()
+				if snapshot.Generation < generation {
+					t.Errorf("Snapshot generation went backwards from %d to %d", generation, snapshot.Generation)
+				}
+				generation = snapshot.Generation
+				if len(snapshot.Pods) != len(names) {
+					t.Errorf("Expected %d pods, got %d", len(names), len(snapshot.Pods))
+				}
+				for _, pm := range snapshot.Pods {
+					checkConsistent(pm)
+				}
+				for _, name := range names {
+					if pm, ok := ds.PodGet(name); ok {
+						checkConsistent(pm)
+					}
+				}
+			}
+		}()
+	}
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	for _, pm := range ds.PodGetAll() {
+		if pm.WaitingQueueSize != 199 || !pm.ServedModels.Serves("199") {
+			t.Errorf("Expected the last update to be stored, got %v", pm)
+		}
+	}
+}
+
 func pointer(v int32) *int32 {
 	return &v
 }
+
+func TestPoolSelector(t *testing.T) {
+	canaryExcluded := &v1.LabelSelector{
+		MatchLabels: map[string]string{"app": "vllm"},
+		MatchExpressions: []v1.LabelSelectorRequirement{
+			{Key: "track", Operator: v1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+		},
+	}
+	tests := []struct {
+		name      string
+		spec      v1alpha1.InferencePoolSpec
+		podLabels map[string]string
+		wantMatch bool
+		wantErr   bool
+	}{
+		{
+			name:      "selector matches",
+			spec:      v1alpha1.InferencePoolSpec{Selector: map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"}},
+			podLabels: map[string]string{"app": "vllm", "track": "canary"},
+			wantMatch: true,
+		},
+		{
+			name:      "labelSelector matches",
+			spec:      v1alpha1.InferencePoolSpec{LabelSelector: canaryExcluded},
+			podLabels: map[string]string{"app": "vllm", "track": "stable"},
+			wantMatch: true,
+		},
+		{
+			name:      "labelSelector excludes",
+			spec:      v1alpha1.InferencePoolSpec{LabelSelector: canaryExcluded},
+			podLabels: map[string]string{"app": "vllm", "track": "canary"},
+			wantMatch: false,
+		},
+		{
+			name: "labelSelector with In set",
+			spec: v1alpha1.InferencePoolSpec{LabelSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{
+					{Key: "app", Operator: v1.LabelSelectorOpIn, Values: []string{"vllm", "sglang"}},
+				},
+			}},
+			podLabels: map[string]string{"app": "sglang"},
+			wantMatch: true,
+		},
+		{
+			name: "both selectors set",
+			spec: v1alpha1.InferencePoolSpec{
+				Selector:      map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"},
+				LabelSelector: canaryExcluded,
+			},
+			podLabels: map[string]string{"app": "vllm"},
+			wantErr:   true,
+		},
+		{
+			name:      "no selector set",
+			spec:      v1alpha1.InferencePoolSpec{},
+			podLabels: map[string]string{"app": "vllm"},
+			wantErr:   true,
+		},
+		{
+			name:      "empty labelSelector",
+			spec:      v1alpha1.InferencePoolSpec{LabelSelector: &v1.LabelSelector{}},
+			podLabels: map[string]string{"app": "vllm"},
+			wantErr:   true,
+		},
+		{
+			name: "invalid operator",
+			spec: v1alpha1.InferencePoolSpec{LabelSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{Key: "app", Operator: "Matches"}},
+			}},
+			podLabels: map[string]string{"app": "vllm"},
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1alpha1.InferencePool{Spec: test.spec}
+			_, err := PoolSelector(pool)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error, got %v, want error %v", err, test.wantErr)
+			}
+			ds := NewFakeDatastore(nil, nil, pool)
+			if got := ds.PoolLabelsMatch(test.podLabels); got != test.wantMatch {
+				t.Errorf("Unexpected match, got %v, want %v", got, test.wantMatch)
+			}
+		})
+	}
+}
+
+func TestPodInFlightRequests(t *testing.T) {
+	ds := NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{
+		Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	})
+	pod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}}
+	name := types.NamespacedName{Name: "pod1", Namespace: "default"}
+	ds.PodUpdateOrAddIfNotExist(pod)
+
+	done1 := ds.PodTrackRequest(name)
+	done2 := ds.PodTrackRequest(name)
+	if got := ds.PodInFlightRequests(name); got != 2 {
+		t.Errorf("Expected 2 in-flight requests, got %d", got)
+	}
+	done1()
+	done1() // Completing a request more than once has no effect.
+	if got := ds.PodInFlightRequests(name); got != 1 {
+		t.Errorf("Expected 1 in-flight request, got %d", got)
+	}
+
+	// Requests are still tracked after the pod is removed.
+	ds.PodDelete(name)
+	if got := ds.PodInFlightRequests(name); got != 1 {
+		t.Errorf("Expected 1 in-flight request after removing the pod, got %d", got)
+	}
+	done2()
+	if got := ds.PodInFlightRequests(name); got != 0 {
+		t.Errorf("Expected no in-flight requests, got %d", got)
+	}
+	if _, ok := ds.(*datastore).inFlight.Load(name); ok {
+		t.Errorf("Expected the counter of the removed pod to be released")
+	}
+}
+
+func TestPodIsDraining(t *testing.T) {
+	now := v1.Now()
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "running",
+			pod:  &corev1.Pod{},
+		},
+		{
+			name: "terminating",
+			pod:  &corev1.Pod{ObjectMeta: v1.ObjectMeta{DeletionTimestamp: &now}},
+			want: true,
+		},
+		{
+			name: "drain annotation",
+			pod:  &corev1.Pod{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{DrainAnnotation: "true"}}},
+			want: true,
+		},
+		{
+			name: "drain annotation disabled",
+			pod:  &corev1.Pod{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{DrainAnnotation: "false"}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := PodIsDraining(test.pod); got != test.want {
+				t.Errorf("Unexpected draining state, got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildScrapeEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           Pod
+		defaultScheme string
+		want          string
+	}{
+		{
+			name: "IPv4",
+			pod:  Pod{Address: "10.0.0.1", ScrapePort: 8000, ScrapePath: "/metrics"},
+			want: "http://10.0.0.1:8000/metrics",
+		},
+		{
+			name: "IPv6",
+			pod:  Pod{Address: "fd00::1", ScrapePort: 8000, ScrapePath: "/metrics"},
+			want: "http://[fd00::1]:8000/metrics",
+		},
+		{
+			name:          "Pod scheme takes precedence",
+			pod:           Pod{Address: "fd00::1", ScrapePort: 8443, ScrapePath: "/metrics", ScrapeScheme: "https"},
+			defaultScheme: "http",
+			want:          "https://[fd00::1]:8443/metrics",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pm := &PodMetrics{Pod: test.pod}
+			if got := pm.BuildScrapeEndpoint(test.defaultScheme); got != test.want {
+				t.Errorf("BuildScrapeEndpoint() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPoolMetricsScheme(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{annotations: nil, want: ""},
+		{annotations: map[string]string{MetricsSchemeAnnotation: "http"}, want: "http"},
+		{annotations: map[string]string{MetricsSchemeAnnotation: "https"}, want: "https"},
+		{annotations: map[string]string{MetricsSchemeAnnotation: "HTTPS"}, wantErr: true},
+		{annotations: map[string]string{MetricsSchemeAnnotation: ""}, wantErr: true},
+	}
+	for _, test := range tests {
+		pool := &v1alpha1.InferencePool{ObjectMeta: v1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+		got, err := PoolMetricsScheme(pool)
+		if test.wantErr != (err != nil) {
+			t.Errorf("PoolMetricsScheme(%v) error = %v, want error %v", test.annotations, err, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("PoolMetricsScheme(%v) = %q, want %q", test.annotations, got, test.want)
+		}
+	}
+}
+
+func TestPoolServiceName(t *testing.T) {
+	pool := &v1alpha1.InferencePool{ObjectMeta: v1.ObjectMeta{Name: "pool"}}
+	if got := PoolServiceName(pool); got != "pool" {
+		t.Errorf("PoolServiceName() = %q, want the pool name", got)
+	}
+	pool.Annotations = map[string]string{ServiceAnnotation: "svc"}
+	if got := PoolServiceName(pool); got != "svc" {
+		t.Errorf("PoolServiceName() = %q, want the annotated service %q", got, "svc")
+	}
+}
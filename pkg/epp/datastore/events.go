@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+// DefaultSubscriptionBufferSize is the event buffer size of a subscription if none is given.
+const DefaultSubscriptionBufferSize = 1024
+
+// EventType is the type of a datastore change.
+type EventType string
+
+const (
+	// EventPodAdded is emitted when a pod is added.
+	EventPodAdded EventType = "PodAdded"
+	// EventPodUpdated is emitted when the properties or the served models of a pod change.
+	EventPodUpdated EventType = "PodUpdated"
+	// EventPodRemoved is emitted when a pod is removed.
+	EventPodRemoved EventType = "PodRemoved"
+	// EventPodMetricsRefreshed is emitted when the metrics of a pod are refreshed.
+	EventPodMetricsRefreshed EventType = "PodMetricsRefreshed"
+	// EventModelSet is emitted when a model is added or updated.
+	EventModelSet EventType = "ModelSet"
+	// EventModelDeleted is emitted when a model is deleted.
+	EventModelDeleted EventType = "ModelDeleted"
+	// EventPoolSet is emitted when the pool is added or updated.
+	EventPoolSet EventType = "PoolSet"
+	// EventPoolCleared is emitted when the store is cleared, i.e. the pool, all models and all pods
+	// were removed. No individual EventPodRemoved and EventModelDeleted events are emitted.
+	EventPoolCleared EventType = "PoolCleared"
+	// EventsDropped is emitted before the first event delivered after events were dropped because
+	// the subscription buffer was full. Subscribers should rebuild their state, e.g. from PodSnapshot.
+	EventsDropped EventType = "EventsDropped"
+)
+
+// Event describes a datastore change.
+type Event struct {
+	Type EventType
+	// Pod is the pod after the change for pod events, or the removed pod for EventPodRemoved.
+	Pod *PodMetrics
+	// PodGeneration is the pods generation after the change for pod events and EventPoolCleared,
+	// see PodSnapshot.
+	PodGeneration uint64
+	// Model is the model after the change for EventModelSet, or the deleted model for
+	// EventModelDeleted.
+	Model *v1alpha1.InferenceModel
+	// Pool is the pool for EventPoolSet.
+	Pool *v1alpha1.InferencePool
+}
+
+// Subscription receives the events of a datastore. Delivery never blocks the datastore: events are
+// buffered, and dropped if the buffer is full.
+type Subscription struct {
+	events  chan Event
+	dropped atomic.Uint64
+	cancel  func(*Subscription)
+
+	// mu guards sending to and closing the events channel.
+	mu      sync.Mutex
+	closed  bool
+	overrun bool
+}
+
+// Events returns the channel the events are delivered on. Events of the same pod are delivered in
+// the order they were applied. The channel is closed on Unsubscribe.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the delivery of events and closes the events channel. It is safe to call
+// Unsubscribe multiple times.
+func (s *Subscription) Unsubscribe() {
+	s.cancel(s)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+func (s *Subscription) send(ev Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.overrun {
+		select {
+		case s.events <- Event{Type: EventsDropped}:
+			s.overrun = false
+		default:
+			s.dropped.Add(1)
+			return
+		}
+	}
+	select {
+	case s.events <- ev:
+	default:
+		s.overrun = true
+		s.dropped.Add(1)
+	}
+}
+
+// subscribers is the set of subscriptions of a datastore.
+type subscribers struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func (s *subscribers) subscribe(bufferSize int) *Subscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriptionBufferSize
+	}
+	sub := &Subscription{
+		events: make(chan Event, bufferSize),
+		cancel: s.unsubscribe,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = map[*Subscription]struct{}{}
+	}
+	s.subs[sub] = struct{}{}
+	return sub
+}
+
+func (s *subscribers) unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, sub)
+}
+
+func (s *subscribers) publish(ev Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for sub := range s.subs {
+		sub.send(ev)
+	}
+}
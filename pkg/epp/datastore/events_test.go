@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+// drain returns the types of the buffered events of a subscription.
+func drain(sub *Subscription) []EventType {
+	var got []EventType
+	for {
+		select {
+		case ev := <-sub.Events():
+			got = append(got, ev.Type)
+		default:
+			return got
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	pool := &v1alpha1.InferencePool{Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000}}
+	ds := NewDatastore()
+	sub := ds.Subscribe(0)
+	defer sub.Unsubscribe()
+
+	pod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}}
+	name := types.NamespacedName{Name: "pod1", Namespace: "default"}
+	model := &v1alpha1.InferenceModel{
+		ObjectMeta: v1.ObjectMeta{Name: "m1", Namespace: "default"},
+		Spec:       v1alpha1.InferenceModelSpec{ModelName: "model1"},
+	}
+
+	ds.PoolSet(pool)
+	ds.PodUpdateOrAddIfNotExist(pod)
+	ds.PodUpdateOrAddIfNotExist(pod) // No-op, no event.
+	ds.PodUpdateMetricsIfExist(name, &Metrics{WaitingQueueSize: 1})
+	ds.PodUpdateServedModelsIfExist(name, &ServedModels{})
+	ds.PodDelete(name)
+	ds.PodDelete(name) // No-op, no event.
+	ds.ModelSet(model)
+	ds.ModelDelete("model1")
+	ds.ModelDelete("model1") // No-op, no event.
+	ds.ModelSet(model)
+	ds.ModelDeleteByNamespacedName(types.NamespacedName{Name: "m1", Namespace: "default"})
+
+	want := []EventType{
+		EventPoolSet,
+		EventPodAdded,
+		EventPodMetricsRefreshed,
+		EventPodUpdated,
+		EventPodRemoved,
+		EventModelSet,
+		EventModelDeleted,
+		EventModelSet,
+		EventModelDeleted,
+	}
+	if diff := cmp.Diff(want, drain(sub)); diff != "" {
+		t.Errorf("Unexpected events (-want +got): %v", diff)
+	}
+
+	// PodDeleteAll emits an event per pod, Clear a single event.
+	ds.PodUpdateOrAddIfNotExist(pod)
+	ds.PodDeleteAll()
+	ds.PodUpdateOrAddIfNotExist(pod)
+	ds.ModelSet(model)
+	ds.Clear()
+	want = []EventType{EventPodAdded, EventPodRemoved, EventPodAdded, EventModelSet, EventPoolCleared}
+	if diff := cmp.Diff(want, drain(sub)); diff != "" {
+		t.Errorf("Unexpected events (-want +got): %v", diff)
+	}
+}
+
+func TestSubscribeEventContent(t *testing.T) {
+	ds := NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000}})
+	sub := ds.Subscribe(0)
+	defer sub.Unsubscribe()
+
+	ds.PodUpdateOrAddIfNotExist(&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}})
+	ds.PodUpdateMetricsIfExist(types.NamespacedName{Name: "pod1", Namespace: "default"}, &Metrics{WaitingQueueSize: 3})
+
+	added := <-sub.Events()
+	refreshed := <-sub.Events()
+	if added.Pod.WaitingQueueSize != 0 || refreshed.Pod.WaitingQueueSize != 3 {
+		t.Errorf("Expected events to carry the pod state after each change, got %v and %v", added.Pod, refreshed.Pod)
+	}
+	if refreshed.PodGeneration != ds.PodSnapshot().Generation || added.PodGeneration >= refreshed.PodGeneration {
+		t.Errorf("Unexpected generations %d and %d, want increasing up to %d", added.PodGeneration, refreshed.PodGeneration, ds.PodSnapshot().Generation)
+	}
+}
+
+func TestSubscribeOverflow(t *testing.T) {
+	ds := NewFakeDatastore(nil, nil, &v1alpha1.InferencePool{Spec: v1alpha1.InferencePoolSpec{TargetPortNumber: 8000}})
+	sub := ds.Subscribe(2)
+	name := types.NamespacedName{Name: "pod1", Namespace: "default"}
+
+	ds.PodUpdateOrAddIfNotExist(&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}})
+	for i := 0; i < 4; i++ {
+		// Delivery must not block even though nobody reads the events.
+		ds.PodUpdateMetricsIfExist(name, &Metrics{WaitingQueueSize: i})
+	}
+	if got := sub.Dropped(); got != 3 {
+		t.Errorf("Expected 3 dropped events, got %d", got)
+	}
+	if diff := cmp.Diff([]EventType{EventPodAdded, EventPodMetricsRefreshed}, drain(sub)); diff != "" {
+		t.Errorf("Unexpected events (-want +got): %v", diff)
+	}
+
+	// The next event is preceded by a notification of the dropped events.
+	ds.PodDelete(name)
+	if diff := cmp.Diff([]EventType{EventsDropped, EventPodRemoved}, drain(sub)); diff != "" {
+		t.Errorf("Unexpected events (-want +got): %v", diff)
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	ds.PodUpdateOrAddIfNotExist(&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod1", Namespace: "default"}})
+	if _, ok := <-sub.Events(); ok {
+		t.Errorf("Expected the events channel to be closed after Unsubscribe")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+// PoolRefName returns the name of the InferencePool referenced by the InferenceModel. The pool is
+// in the namespace of the model, unless the reference sets another namespace.
+func PoolRefName(model *v1alpha1.InferenceModel) types.NamespacedName {
+	namespace := model.Spec.PoolRef.Namespace
+	if namespace == "" {
+		namespace = model.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: model.Spec.PoolRef.Name}
+}
+
+// ReferenceGranted returns true if the InferenceModel may reference its InferencePool. A pool in
+// the namespace of the model can always be referenced, a pool in another namespace only if one of
+// the InferencePoolGrants in the namespace of the pool allows it. Grants in other namespaces are
+// ignored.
+func ReferenceGranted(model *v1alpha1.InferenceModel, grants []v1alpha1.InferencePoolGrant) bool {
+	pool := PoolRefName(model)
+	if pool.Namespace == model.Namespace {
+		return true
+	}
+	for i := range grants {
+		if grants[i].Namespace == pool.Namespace && grantAllows(&grants[i], model.Namespace, pool.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func grantAllows(grant *v1alpha1.InferencePoolGrant, from, pool string) bool {
+	fromAllowed := false
+	for _, f := range grant.Spec.From {
+		if f.Namespace == from {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	for _, to := range grant.Spec.To {
+		if to.Name == nil || *to.Name == pool {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+func TestPoolRefName(t *testing.T) {
+	model := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "team"},
+		Spec:       v1alpha1.InferenceModelSpec{PoolRef: v1alpha1.PoolObjectReference{Name: "pool"}},
+	}
+	if diff := cmp.Diff(types.NamespacedName{Namespace: "team", Name: "pool"}, PoolRefName(model)); diff != "" {
+		t.Errorf("Unexpected pool of a same-namespace reference (-want +got): %s", diff)
+	}
+	model.Spec.PoolRef.Namespace = "platform"
+	if diff := cmp.Diff(types.NamespacedName{Namespace: "platform", Name: "pool"}, PoolRefName(model)); diff != "" {
+		t.Errorf("Unexpected pool of a cross-namespace reference (-want +got): %s", diff)
+	}
+}
+
+func TestReferenceGranted(t *testing.T) {
+	grant := func(namespace string, from []string, to ...*string) v1alpha1.InferencePoolGrant {
+		g := v1alpha1.InferencePoolGrant{ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: namespace}}
+		for _, ns := range from {
+			g.Spec.From = append(g.Spec.From, v1alpha1.InferencePoolGrantFrom{Namespace: ns})
+		}
+		for _, name := range to {
+			g.Spec.To = append(g.Spec.To, v1alpha1.InferencePoolGrantTo{Name: name})
+		}
+		return g
+	}
+	model := func(namespace, poolNamespace string) *v1alpha1.InferenceModel {
+		return &v1alpha1.InferenceModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: namespace},
+			Spec:       v1alpha1.InferenceModelSpec{PoolRef: v1alpha1.PoolObjectReference{Namespace: poolNamespace, Name: "pool"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		model  *v1alpha1.InferenceModel
+		grants []v1alpha1.InferencePoolGrant
+		want   bool
+	}{
+		{
+			name:  "same namespace without grant",
+			model: model("platform", ""),
+			want:  true,
+		},
+		{
+			name:  "explicit same namespace without grant",
+			model: model("platform", "platform"),
+			want:  true,
+		},
+		{
+			name:  "cross namespace without grant",
+			model: model("team", "platform"),
+			want:  false,
+		},
+		{
+			name:   "grant for all pools",
+			model:  model("team", "platform"),
+			grants: []v1alpha1.InferencePoolGrant{grant("platform", []string{"other", "team"}, nil)},
+			want:   true,
+		},
+		{
+			name:   "grant for the pool",
+			model:  model("team", "platform"),
+			grants: []v1alpha1.InferencePoolGrant{grant("platform", []string{"team"}, ptr.To("other-pool"), ptr.To("pool"))},
+			want:   true,
+		},
+		{
+			name:   "grant for another pool",
+			model:  model("team", "platform"),
+			grants: []v1alpha1.InferencePoolGrant{grant("platform", []string{"team"}, ptr.To("other-pool"))},
+			want:   false,
+		},
+		{
+			name:   "grant for another namespace",
+			model:  model("team", "platform"),
+			grants: []v1alpha1.InferencePoolGrant{grant("platform", []string{"other"}, nil)},
+			want:   false,
+		},
+		{
+			name:   "grant outside the pool namespace",
+			model:  model("team", "platform"),
+			grants: []v1alpha1.InferencePoolGrant{grant("team", []string{"team"}, nil)},
+			want:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ReferenceGranted(test.model, test.grants); got != test.want {
+				t.Errorf("Expected ReferenceGranted %t, got %t", test.want, got)
+			}
+		})
+	}
+}
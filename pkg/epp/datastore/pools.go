@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Pools maps the InferencePools served by the EPP to their datastores. It is populated at startup
+// and must not be modified afterwards, so it can be read concurrently without locking.
+type Pools map[types.NamespacedName]Datastore
+
+// HasSynced returns true if all pools have synced.
+func (p Pools) HasSynced() bool {
+	for _, ds := range p {
+		if !ds.PoolHasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// InNamespace returns the datastores of the pools in the given namespace.
+func (p Pools) InNamespace(namespace string) map[types.NamespacedName]Datastore {
+	res := map[types.NamespacedName]Datastore{}
+	for name, ds := range p {
+		if name.Namespace == namespace {
+			res[name] = ds
+		}
+	}
+	return res
+}
+
+// ParsePoolName parses a pool reference of the form "namespace/name" or "name". The default
+// namespace is used if the reference has no namespace.
+func ParsePoolName(ref, defaultNamespace string) (types.NamespacedName, error) {
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found {
+		namespace, name = defaultNamespace, ref
+	}
+	if namespace == "" || name == "" || strings.Contains(name, "/") {
+		return types.NamespacedName{}, fmt.Errorf("invalid InferencePool reference %q, must be <namespace>/<name> or <name>", ref)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
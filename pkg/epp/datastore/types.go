@@ -19,8 +19,12 @@ package datastore
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type Pod struct {
@@ -30,6 +34,13 @@ type Pod struct {
 	// metrics scrape options
 	ScrapePort int32
 	ScrapePath string
+	// ScrapeScheme overrides the default scheme used to scrape metrics, if set.
+	ScrapeScheme string
+
+	// Draining is true if the pod is terminating or marked with the DrainAnnotation. Draining pods
+	// do not get new requests, but are kept until they are deleted so that their in-flight requests
+	// are still tracked.
+	Draining bool
 }
 
 type Metrics struct {
@@ -41,15 +52,69 @@ type Metrics struct {
 	WaitingQueueSize        int
 	KVCacheUsagePercent     float64
 	KvCacheMaxTokenCapacity int
+
+	// Metrics derived from model server counters and histograms over a short window. They are zero
+	// until the window has at least two scrapes, or if the model server does not export them.
+	PromptTokensPerSecond       float64
+	GenerationTokensPerSecond   float64
+	TimeToFirstTokenP90Seconds  float64
+	E2ERequestLatencyP90Seconds float64
+
+	// UpdateTime is the time the metrics were last refreshed successfully. It is zero until the
+	// first successful scrape.
+	UpdateTime time.Time
+	// ConsecutiveScrapeFailures is the number of scrapes that failed in a row since UpdateTime.
+	ConsecutiveScrapeFailures int
+}
+
+// ServedModels are the models a model server reported to serve via its models endpoint.
+type ServedModels struct {
+	// BaseModels is the set of base models served.
+	BaseModels sets.Set[string]
+	// Adapters maps the served LoRA adapters to their base models.
+	Adapters map[string]string
+	// UpdateTime is the time the served models were last discovered. It is zero if model
+	// discovery is disabled or did not succeed yet, in which case the served models are unknown.
+	UpdateTime time.Time
+}
+
+// Known returns true if the served models were discovered.
+func (sm *ServedModels) Known() bool {
+	return !sm.UpdateTime.IsZero()
+}
+
+// Serves returns true if the given model is a served base model or adapter.
+func (sm *ServedModels) Serves(model string) bool {
+	if sm.BaseModels.Has(model) {
+		return true
+	}
+	_, ok := sm.Adapters[model]
+	return ok
+}
+
+func (sm *ServedModels) Clone() ServedModels {
+	clone := ServedModels{UpdateTime: sm.UpdateTime}
+	if sm.BaseModels != nil {
+		clone.BaseModels = sm.BaseModels.Clone()
+	}
+	if sm.Adapters != nil {
+		clone.Adapters = make(map[string]string, len(sm.Adapters))
+		for k, v := range sm.Adapters {
+			clone.Adapters[k] = v
+		}
+	}
+	return clone
 }
 
 type PodMetrics struct {
 	Pod
 	Metrics
+	// ServedModels is updated independently of the metrics, by the model discovery.
+	ServedModels ServedModels
 }
 
 func (pm *PodMetrics) String() string {
-	return fmt.Sprintf("Pod: %+v; Address: %+v; Metrics: %+v", pm.NamespacedName, pm.Address, pm.Metrics)
+	return fmt.Sprintf("Pod: %+v; Address: %+v; Metrics: %+v; ServedModels: %+v", pm.NamespacedName, pm.Address, pm.Metrics, pm.ServedModels)
 }
 
 func (pm *PodMetrics) Clone() *PodMetrics {
@@ -63,18 +128,68 @@ func (pm *PodMetrics) Clone() *PodMetrics {
 			Address:        pm.Address,
 			ScrapePort:     pm.ScrapePort,
 			ScrapePath:     pm.ScrapePath,
+			ScrapeScheme:   pm.ScrapeScheme,
 		},
 		Metrics: Metrics{
-			ActiveModels:            cm,
-			RunningQueueSize:        pm.RunningQueueSize,
-			WaitingQueueSize:        pm.WaitingQueueSize,
-			KVCacheUsagePercent:     pm.KVCacheUsagePercent,
-			KvCacheMaxTokenCapacity: pm.KvCacheMaxTokenCapacity,
+			ActiveModels:                cm,
+			MaxActiveModels:             pm.MaxActiveModels,
+			RunningQueueSize:            pm.RunningQueueSize,
+			WaitingQueueSize:            pm.WaitingQueueSize,
+			KVCacheUsagePercent:         pm.KVCacheUsagePercent,
+			KvCacheMaxTokenCapacity:     pm.KvCacheMaxTokenCapacity,
+			PromptTokensPerSecond:       pm.PromptTokensPerSecond,
+			GenerationTokensPerSecond:   pm.GenerationTokensPerSecond,
+			TimeToFirstTokenP90Seconds:  pm.TimeToFirstTokenP90Seconds,
+			E2ERequestLatencyP90Seconds: pm.E2ERequestLatencyP90Seconds,
+			UpdateTime:                  pm.UpdateTime,
+			ConsecutiveScrapeFailures:   pm.ConsecutiveScrapeFailures,
 		},
+		ServedModels: pm.ServedModels.Clone(),
 	}
 	return clone
 }
 
-func (pm *PodMetrics) BuildScrapeEndpoint() string {
-	return fmt.Sprintf("http://%s:%d%s", pm.Address, pm.ScrapePort, pm.ScrapePath)
+// IsStale returns true if the metrics were not refreshed within the given threshold as of now.
+// Pods that have never been scraped successfully are always stale. A non-positive threshold
+// disables staleness tracking.
+func (pm *PodMetrics) IsStale(threshold time.Duration, now time.Time) bool {
+	if threshold <= 0 {
+		return false
+	}
+	return pm.UpdateTime.IsZero() || now.Sub(pm.UpdateTime) > threshold
+}
+
+// CountReadyPods returns the number of the given pods that are not draining, and how many of them
+// have fresh metrics, that is metrics scraped successfully within the staleness threshold as of
+// now. A non-positive threshold only requires the metrics to have been scraped once.
+func CountReadyPods(pods []*PodMetrics, threshold time.Duration, now time.Time) (ready, fresh int) {
+	for _, pm := range pods {
+		if pm.Draining {
+			continue
+		}
+		ready++
+		if !pm.UpdateTime.IsZero() && !pm.IsStale(threshold, now) {
+			fresh++
+		}
+	}
+	return ready, fresh
+}
+
+// BuildScrapeEndpoint returns the metrics URL of the pod. The pod's own scrape scheme takes
+// precedence over the given default scheme, and "http" is used if neither is set.
+func (pm *PodMetrics) BuildScrapeEndpoint(defaultScheme string) string {
+	return pm.BuildEndpoint(defaultScheme, pm.ScrapePath)
+}
+
+// BuildEndpoint returns the URL of the given path on the model server port of the pod, using the
+// same scheme as BuildScrapeEndpoint.
+func (pm *PodMetrics) BuildEndpoint(defaultScheme, path string) string {
+	scheme := pm.ScrapeScheme
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if scheme == "" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(pm.Address, strconv.Itoa(int(pm.ScrapePort))), path)
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+// MaxTargetModelWeight is the maximum weight of a target model.
+const MaxTargetModelWeight = 1000000
+
+// ValidateInferenceModel returns the errors that make the InferenceModel unusable at request
+// time. The reconcilers ignore invalid InferenceModels, and the validating webhook rejects them.
+func ValidateInferenceModel(model *v1alpha1.InferenceModel) field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+	if model.Spec.ModelName == "" {
+		errs = append(errs, field.Required(spec.Child("modelName"), ""))
+	}
+	if model.Spec.PoolRef.Name == "" {
+		errs = append(errs, field.Required(spec.Child("poolRef", "name"), ""))
+	}
+
+	targetModels := spec.Child("targetModels")
+	names := sets.New[string]()
+	weighted, total := 0, int64(0)
+	for i, tm := range model.Spec.TargetModels {
+		path := targetModels.Index(i)
+		if tm.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), ""))
+		} else if names.Has(tm.Name) {
+			errs = append(errs, field.Duplicate(path.Child("name"), tm.Name))
+		}
+		names.Insert(tm.Name)
+		if tm.Weight == nil {
+			continue
+		}
+		weighted++
+		if *tm.Weight < 0 || *tm.Weight > MaxTargetModelWeight {
+			errs = append(errs, field.Invalid(path.Child("weight"), *tm.Weight, "must be between 0 and 1000000"))
+		} else {
+			total += int64(*tm.Weight)
+		}
+	}
+	if weighted > 0 && weighted < len(model.Spec.TargetModels) {
+		errs = append(errs, field.Invalid(targetModels, weighted, "weights should be set for all models, or none of the models"))
+	} else if weighted > 0 && total == 0 {
+		errs = append(errs, field.Invalid(targetModels, total, "the total weight of the target models must be greater than zero"))
+	}
+	return errs
+}
+
+// ValidateInferencePool returns the errors that make the InferencePool unusable. The reconcilers
+// ignore invalid InferencePools, and the validating webhook rejects them.
+func ValidateInferencePool(pool *v1alpha1.InferencePool) field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+	if len(pool.Spec.Selector) == 0 && pool.Spec.LabelSelector == nil {
+		errs = append(errs, field.Required(spec.Child("selector"), "exactly one of selector and labelSelector must be set"))
+	} else if ls := pool.Spec.LabelSelector; ls != nil && len(ls.MatchLabels) == 0 && len(ls.MatchExpressions) == 0 {
+		// An empty label selector would select every pod in the namespace.
+		errs = append(errs, field.Required(spec.Child("labelSelector"), "matchLabels or matchExpressions must be set"))
+	} else if _, err := PoolSelector(pool); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("labelSelector"), pool.Spec.LabelSelector, err.Error()))
+	}
+	if pool.Spec.TargetPortNumber < 1 || pool.Spec.TargetPortNumber > 65535 {
+		errs = append(errs, field.Invalid(spec.Child("targetPortNumber"), pool.Spec.TargetPortNumber, "must be between 1 and 65535"))
+	}
+	if _, err := PoolMetricsScheme(pool); err != nil {
+		errs = append(errs, field.NotSupported(field.NewPath("metadata", "annotations").Key(MetricsSchemeAnnotation), pool.Annotations[MetricsSchemeAnnotation], []string{"http", "https"}))
+	}
+	return errs
+}
+
+// InferenceModelPrecedes returns true if the InferenceModel a takes precedence over b when both
+// use the same model name in a pool: the oldest one by creation timestamp is accepted, and ties
+// are broken by namespace and name.
+func InferenceModelPrecedes(a, b *v1alpha1.InferenceModel) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+func TestValidateInferenceModel(t *testing.T) {
+	model := func(targetModels ...v1alpha1.TargetModel) *v1alpha1.InferenceModel {
+		return &v1alpha1.InferenceModel{
+			ObjectMeta: v1.ObjectMeta{Name: "model"},
+			Spec: v1alpha1.InferenceModelSpec{
+				ModelName:    "llama",
+				PoolRef:      v1alpha1.PoolObjectReference{Name: "pool"},
+				TargetModels: targetModels,
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		model *v1alpha1.InferenceModel
+		want  field.ErrorList
+	}{
+		{
+			name:  "Valid without target models",
+			model: model(),
+		},
+		{
+			name:  "Valid without weights",
+			model: model(v1alpha1.TargetModel{Name: "v1"}, v1alpha1.TargetModel{Name: "v2"}),
+		},
+		{
+			name:  "Valid with a zero weight",
+			model: model(v1alpha1.TargetModel{Name: "v1", Weight: pointer(0)}, v1alpha1.TargetModel{Name: "v2", Weight: pointer(10)}),
+		},
+		{
+			name: "Missing model name and pool",
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: v1.ObjectMeta{Name: "model"},
+			},
+			want: field.ErrorList{
+				field.Required(field.NewPath("spec", "modelName"), ""),
+				field.Required(field.NewPath("spec", "poolRef", "name"), ""),
+			},
+		},
+		{
+			name:  "All weights zero",
+			model: model(v1alpha1.TargetModel{Name: "v1", Weight: pointer(0)}, v1alpha1.TargetModel{Name: "v2", Weight: pointer(0)}),
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "targetModels"), int64(0), ""),
+			},
+		},
+		{
+			name:  "Weights partially set",
+			model: model(v1alpha1.TargetModel{Name: "v1", Weight: pointer(10)}, v1alpha1.TargetModel{Name: "v2"}),
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "targetModels"), 1, ""),
+			},
+		},
+		{
+			name:  "Duplicate and missing target model names",
+			model: model(v1alpha1.TargetModel{Name: "v1"}, v1alpha1.TargetModel{Name: "v1"}, v1alpha1.TargetModel{}),
+			want: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "targetModels").Index(1).Child("name"), "v1"),
+				field.Required(field.NewPath("spec", "targetModels").Index(2).Child("name"), ""),
+			},
+		},
+		{
+			name:  "Weight out of range",
+			model: model(v1alpha1.TargetModel{Name: "v1", Weight: pointer(MaxTargetModelWeight + 1)}),
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "targetModels").Index(0).Child("weight"), int32(MaxTargetModelWeight+1), ""),
+				field.Invalid(field.NewPath("spec", "targetModels"), int64(0), ""),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ValidateInferenceModel(test.model)
+			if diff := cmp.Diff(test.want, got, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("Unexpected errors (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateInferencePool(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      *v1alpha1.InferencePool
+		wantPaths []string
+	}{
+		{
+			name: "Valid",
+			pool: &v1alpha1.InferencePool{
+				ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{MetricsSchemeAnnotation: "https"}},
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: 8000,
+					Selector:         map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"},
+				},
+			},
+		},
+		{
+			name:      "Missing selector and port",
+			pool:      &v1alpha1.InferencePool{},
+			wantPaths: []string{"spec.selector", "spec.targetPortNumber"},
+		},
+		{
+			name: "Empty label selector",
+			pool: &v1alpha1.InferencePool{
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: 8000,
+					LabelSelector:    &v1.LabelSelector{},
+				},
+			},
+			wantPaths: []string{"spec.labelSelector"},
+		},
+		{
+			name: "Invalid label selector and metrics scheme",
+			pool: &v1alpha1.InferencePool{
+				ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{MetricsSchemeAnnotation: "ftp"}},
+				Spec: v1alpha1.InferencePoolSpec{
+					TargetPortNumber: 8000,
+					LabelSelector: &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{
+						{Key: "app", Operator: "Unknown"},
+					}},
+				},
+			},
+			wantPaths: []string{"spec.labelSelector", "metadata.annotations[inference.networking.x-k8s.io/metrics-scheme]"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotPaths []string
+			for _, err := range ValidateInferencePool(test.pool) {
+				gotPaths = append(gotPaths, err.Field)
+			}
+			if diff := cmp.Diff(test.wantPaths, gotPaths); diff != "" {
+				t.Errorf("Unexpected invalid fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInferenceModelPrecedes(t *testing.T) {
+	older := &v1alpha1.InferenceModel{ObjectMeta: v1.ObjectMeta{Name: "z", CreationTimestamp: v1.Unix(1, 0)}}
+	newer := &v1alpha1.InferenceModel{ObjectMeta: v1.ObjectMeta{Name: "a", CreationTimestamp: v1.Unix(2, 0)}}
+	sameTime := &v1alpha1.InferenceModel{ObjectMeta: v1.ObjectMeta{Name: "b", CreationTimestamp: v1.Unix(2, 0)}}
+	if !InferenceModelPrecedes(older, newer) || InferenceModelPrecedes(newer, older) {
+		t.Errorf("Expected the older InferenceModel to take precedence")
+	}
+	if !InferenceModelPrecedes(newer, sameTime) || InferenceModelPrecedes(sameTime, newer) {
+		t.Errorf("Expected ties to be broken by name")
+	}
+	otherNamespace := &v1alpha1.InferenceModel{ObjectMeta: v1.ObjectMeta{Name: "a", Namespace: "z", CreationTimestamp: v1.Unix(2, 0)}}
+	if !InferenceModelPrecedes(sameTime, otherNamespace) || InferenceModelPrecedes(otherNamespace, sameTime) {
+		t.Errorf("Expected ties to be broken by namespace before name")
+	}
+}
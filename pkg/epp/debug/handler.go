@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debug implements the debug HTTP API of the EPP, exposing what the EPP believes about the
+// pods, models and pools it serves, and how it would schedule a given request.
+package debug
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// Paths of the debug endpoints. All endpoints take an optional pool query parameter selecting the
+// pool, either "namespace/name" or a "name" unique across the served pools. It is required if the
+// EPP serves multiple pools.
+const (
+	// PodsPath lists the pods of the pool with their current metrics and freshness.
+	PodsPath = "/debug/pods"
+	// ModelsPath lists the InferenceModels of the pool with their resolved target models.
+	ModelsPath = "/debug/models"
+	// PoolPath returns the InferencePool and the scheduler config in effect for it.
+	PoolPath = "/debug/pool"
+	// DryRunPath takes a request body by POST, and returns the decision of the scheduler for it:
+	// the filters applied and the candidate pods. No target pod is picked and nothing is dispatched.
+	DryRunPath = "/debug/dryrun"
+	// DecisionsPath lists the most recent scheduling decisions of the pool, oldest first.
+	DecisionsPath = "/debug/decisions"
+)
+
+// maxDryRunBodySize limits the size of the request bodies of the dry-run endpoint.
+const maxDryRunBodySize = 10 << 20
+
+// Scheduler is the part of the scheduler used by the debug endpoints.
+type Scheduler interface {
+	Config(ctx context.Context) scheduling.Config
+	DryRun(ctx context.Context, req *scheduling.LLMRequest) *scheduling.Decision
+}
+
+// Pool holds the components serving a single InferencePool.
+type Pool struct {
+	Datastore datastore.Datastore
+	Scheduler Scheduler
+}
+
+// NewHandler returns the handler of the debug endpoints of the given pools. The decisions are
+// those recorded by the schedulers of the pools, nil if they are not recorded. The handler does
+// not authenticate requests, see WithBearerToken.
+func NewHandler(pools map[types.NamespacedName]*Pool, decisions *scheduling.DecisionLog) http.Handler {
+	h := &handler{pools: pools, decisions: decisions}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PodsPath, h.get(h.pods))
+	mux.HandleFunc(ModelsPath, h.get(h.models))
+	mux.HandleFunc(PoolPath, h.get(h.pool))
+	mux.HandleFunc(DryRunPath, h.dryRun)
+	mux.HandleFunc(DecisionsPath, h.get(h.recentDecisions))
+	return mux
+}
+
+// WithBearerToken returns a handler only passing requests with the given bearer token to h.
+func WithBearerToken(h http.Handler, token string) http.Handler {
+	return withBearerToken(h, func() (string, error) { return token, nil })
+}
+
+// WithBearerTokenFile returns a handler only passing requests with the bearer token of the given
+// file to h. The file is re-read when it changes, so that the token can be rotated without
+// restarting the EPP. It returns an error if the file cannot be read.
+func WithBearerTokenFile(h http.Handler, path string) (http.Handler, error) {
+	f := &tokenFile{path: path}
+	if _, err := f.get(); err != nil {
+		return nil, err
+	}
+	return withBearerToken(h, f.get), nil
+}
+
+func withBearerToken(h http.Handler, token func() (string, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want, err := token()
+		if err != nil {
+			log.FromContext(r.Context()).Error(err, "Failed to get the debug bearer token")
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// tokenFile caches the content of a token file, re-reading it when its modification time changes.
+type tokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func (f *tokenFile) get() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat bearer token file %q: %w", f.path, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && info.ModTime().Equal(f.modTime) {
+		return f.token, nil
+	}
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read bearer token file %q: %w", f.path, err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("bearer token file %q is empty", f.path)
+	}
+	f.token = token
+	f.modTime = info.ModTime()
+	return f.token, nil
+}
+
+type handler struct {
+	pools     map[types.NamespacedName]*Pool
+	decisions *scheduling.DecisionLog
+}
+
+// PodInfo is a pod as seen by the scheduler.
+type PodInfo struct {
+	Name             string `json:"name"`
+	Address          string `json:"address"`
+	Draining         bool   `json:"draining"`
+	InFlightRequests int    `json:"inFlightRequests"`
+
+	ActiveModels                map[string]int `json:"activeModels,omitempty"`
+	MaxActiveModels             int            `json:"maxActiveModels"`
+	RunningQueueSize            int            `json:"runningQueueSize"`
+	WaitingQueueSize            int            `json:"waitingQueueSize"`
+	KVCacheUsagePercent         float64        `json:"kvCacheUsagePercent"`
+	KVCacheMaxTokenCapacity     int            `json:"kvCacheMaxTokenCapacity"`
+	PromptTokensPerSecond       float64        `json:"promptTokensPerSecond"`
+	GenerationTokensPerSecond   float64        `json:"generationTokensPerSecond"`
+	TimeToFirstTokenP90Seconds  float64        `json:"timeToFirstTokenP90Seconds"`
+	E2ERequestLatencyP90Seconds float64        `json:"e2eRequestLatencyP90Seconds"`
+
+	// MetricsUpdateTime is the time the metrics were last refreshed, nil before the first scrape.
+	MetricsUpdateTime *time.Time `json:"metricsUpdateTime,omitempty"`
+	// MetricsAge is the time since the metrics were last refreshed, e.g. "1.2s".
+	MetricsAge                string `json:"metricsAge,omitempty"`
+	MetricsStale              bool   `json:"metricsStale"`
+	ConsecutiveScrapeFailures int    `json:"consecutiveScrapeFailures"`
+
+	// ServedModels are the discovered base models and adapters, nil if they are unknown.
+	ServedModels []string `json:"servedModels,omitempty"`
+}
+
+// ModelInfo is an InferenceModel with its resolved target models.
+type ModelInfo struct {
+	// Name is the namespaced name of the InferenceModel.
+	Name        string `json:"name"`
+	ModelName   string `json:"modelName"`
+	Criticality string `json:"criticality"`
+	// Targets are the target models a request for the model is sent to.
+	Targets []TargetInfo `json:"targets"`
+}
+
+// TargetInfo is a target model with its share of the requests.
+type TargetInfo struct {
+	Name   string  `json:"name"`
+	Weight int32   `json:"weight"`
+	Share  float64 `json:"share"`
+}
+
+// PoolInfo is an InferencePool with the scheduler config in effect for it.
+type PoolInfo struct {
+	Name string `json:"name"`
+	// Synced is false until the InferencePool was read, Pool is nil then.
+	Synced          bool                    `json:"synced"`
+	Pool            *v1alpha1.InferencePool `json:"pool,omitempty"`
+	SchedulerConfig SchedulerConfigInfo     `json:"schedulerConfig"`
+}
+
+// SchedulerConfigInfo is the JSON representation of a scheduling.Config.
+type SchedulerConfigInfo struct {
+	MetricsStalenessThreshold string                     `json:"metricsStalenessThreshold"`
+	StalenessPolicy           scheduling.StalenessPolicy `json:"stalenessPolicy"`
+	KVCacheThreshold          float64                    `json:"kvCacheThreshold"`
+	QueueThresholdCritical    int                        `json:"queueThresholdCritical"`
+	QueueingThresholdLoRA     int                        `json:"queueingThresholdLoRA"`
+	Picker                    scheduling.Picker          `json:"picker"`
+}
+
+// get returns a handler of GET requests calling f with the selected pool.
+func (h *handler) get(f func(context.Context, types.NamespacedName, *Pool) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name, pool, err := h.selectPool(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(r.Context(), w, f(r.Context(), name, pool))
+	}
+}
+
+func (h *handler) pods(ctx context.Context, _ types.NamespacedName, pool *Pool) any {
+	threshold := pool.Scheduler.Config(ctx).MetricsStalenessThreshold
+	now := time.Now()
+	pods := []PodInfo{}
+	for _, pm := range pool.Datastore.PodSnapshot().Pods {
+		info := PodInfo{
+			Name:                        pm.NamespacedName.String(),
+			Address:                     pm.Address,
+			Draining:                    pm.Draining,
+			InFlightRequests:            pool.Datastore.PodInFlightRequests(pm.NamespacedName),
+			ActiveModels:                pm.ActiveModels,
+			MaxActiveModels:             pm.MaxActiveModels,
+			RunningQueueSize:            pm.RunningQueueSize,
+			WaitingQueueSize:            pm.WaitingQueueSize,
+			KVCacheUsagePercent:         pm.KVCacheUsagePercent,
+			KVCacheMaxTokenCapacity:     pm.KvCacheMaxTokenCapacity,
+			PromptTokensPerSecond:       pm.PromptTokensPerSecond,
+			GenerationTokensPerSecond:   pm.GenerationTokensPerSecond,
+			TimeToFirstTokenP90Seconds:  pm.TimeToFirstTokenP90Seconds,
+			E2ERequestLatencyP90Seconds: pm.E2ERequestLatencyP90Seconds,
+			MetricsStale:                pm.IsStale(threshold, now),
+			ConsecutiveScrapeFailures:   pm.ConsecutiveScrapeFailures,
+		}
+		if !pm.UpdateTime.IsZero() {
+			updateTime := pm.UpdateTime
+			info.MetricsUpdateTime = &updateTime
+			info.MetricsAge = now.Sub(updateTime).String()
+		}
+		if pm.ServedModels.Known() {
+			info.ServedModels = append(info.ServedModels, pm.ServedModels.BaseModels.UnsortedList()...)
+			for adapter := range pm.ServedModels.Adapters {
+				info.ServedModels = append(info.ServedModels, adapter)
+			}
+			sort.Strings(info.ServedModels)
+		}
+		pods = append(pods, info)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods
+}
+
+func (h *handler) models(_ context.Context, _ types.NamespacedName, pool *Pool) any {
+	models := []ModelInfo{}
+	for _, model := range pool.Datastore.ModelGetAll() {
+		info := ModelInfo{
+			Name:        types.NamespacedName{Namespace: model.Namespace, Name: model.Name}.String(),
+			ModelName:   model.Spec.ModelName,
+			Criticality: string(v1alpha1.Standard),
+			Targets:     []TargetInfo{},
+		}
+		if model.Spec.Criticality != nil {
+			info.Criticality = string(*model.Spec.Criticality)
+		}
+		if len(model.Spec.TargetModels) == 0 {
+			// Requests are sent to the model name itself.
+			info.Targets = append(info.Targets, TargetInfo{Name: model.Spec.ModelName, Weight: 1, Share: 1})
+		}
+		var total int32
+		for _, target := range model.Spec.TargetModels {
+			total += datastore.TargetModelWeight(target)
+		}
+		for _, target := range model.Spec.TargetModels {
+			weight := datastore.TargetModelWeight(target)
+			info.Targets = append(info.Targets, TargetInfo{Name: target.Name, Weight: weight, Share: share(weight, total)})
+		}
+		models = append(models, info)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ModelName < models[j].ModelName })
+	return models
+}
+
+func share(weight, total int32) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(weight) / float64(total)
+}
+
+func (h *handler) pool(ctx context.Context, name types.NamespacedName, pool *Pool) any {
+	config := pool.Scheduler.Config(ctx)
+	info := PoolInfo{
+		Name: name.String(),
+		SchedulerConfig: SchedulerConfigInfo{
+			MetricsStalenessThreshold: config.MetricsStalenessThreshold.String(),
+			StalenessPolicy:           config.StalenessPolicy,
+			KVCacheThreshold:          config.KVCacheThreshold,
+			QueueThresholdCritical:    config.QueueThresholdCritical,
+			QueueingThresholdLoRA:     config.QueueingThresholdLoRA,
+			Picker:                    config.Picker,
+		},
+	}
+	if p, err := pool.Datastore.PoolGet(); err == nil {
+		info.Synced = true
+		info.Pool = p
+	}
+	return info
+}
+
+func (h *handler) recentDecisions(_ context.Context, name types.NamespacedName, _ *Pool) any {
+	decisions := []*scheduling.Decision{}
+	if h.decisions == nil {
+		return decisions
+	}
+	for _, d := range h.decisions.Decisions() {
+		if d.Pool == name.String() {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions
+}
+
+func (h *handler) dryRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	_, pool, err := h.selectPool(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDryRunBodySize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		http.Error(w, fmt.Sprintf("error unmarshaling request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	req, err := handlers.NewLLMRequest(log.FromContext(r.Context()), pool.Datastore, body)
+	if err != nil {
+		status := http.StatusBadRequest
+		var e errutil.Error
+		if errors.As(err, &e) && e.Code == errutil.BadConfiguration {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(r.Context(), w, pool.Scheduler.DryRun(r.Context(), req))
+}
+
+// selectPool returns the pool selected by the pool query parameter of the request.
+func (h *handler) selectPool(r *http.Request) (types.NamespacedName, *Pool, error) {
+	selector := r.URL.Query().Get("pool")
+	if selector == "" {
+		if len(h.pools) == 1 {
+			for name, pool := range h.pools {
+				return name, pool, nil
+			}
+		}
+		return types.NamespacedName{}, nil, errors.New("the pool query parameter is required if multiple InferencePools are served")
+	}
+	var (
+		found types.NamespacedName
+		pool  *Pool
+	)
+	for name, p := range h.pools {
+		if name.String() != selector && name.Name != selector {
+			continue
+		}
+		if pool != nil {
+			return types.NamespacedName{}, nil, fmt.Errorf("InferencePool %q is ambiguous, use <namespace>/<name>", selector)
+		}
+		found, pool = name, p
+	}
+	if pool == nil {
+		return types.NamespacedName{}, nil, fmt.Errorf("InferencePool %q is not served", selector)
+	}
+	return found, pool, nil
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Failed to write debug response")
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+)
+
+func newTestHandler(t *testing.T) (http.Handler, datastore.Datastore) {
+	t.Helper()
+	ds := datastore.NewDatastore()
+	ds.PoolSet(&v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+	})
+	ds.ModelSet(&v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "default"},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName:   "chat",
+			Criticality: ptr.To(v1alpha1.Critical),
+			TargetModels: []v1alpha1.TargetModel{
+				{Name: "chat-v1", Weight: ptr.To[int32](30)},
+				{Name: "chat-v2", Weight: ptr.To[int32](10)},
+			},
+		},
+	})
+	ds.ModelSet(&v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec:       v1alpha1.InferenceModelSpec{ModelName: "base"},
+	})
+	for _, name := range []string{"pod-1", "pod-2"} {
+		nn := types.NamespacedName{Namespace: "default", Name: name}
+		ds.PodUpdateOrAddEndpointIfNotExist(nn, "10.0.0."+name[len(name)-1:], false)
+	}
+	ds.PodUpdateMetricsIfExist(types.NamespacedName{Namespace: "default", Name: "pod-1"}, &datastore.Metrics{
+		UpdateTime:          time.Now(),
+		WaitingQueueSize:    3,
+		KVCacheUsagePercent: 0.5,
+		MaxActiveModels:     4,
+	})
+	done := ds.PodTrackRequest(types.NamespacedName{Namespace: "default", Name: "pod-1"})
+	t.Cleanup(done)
+
+	scheduler := scheduling.NewScheduler(ds)
+	decisions := scheduling.NewDecisionLog(10)
+	scheduler.SetDecisionRecorder(decisions)
+	if _, err := scheduler.Schedule(context.Background(), &scheduling.LLMRequest{Model: "base", ResolvedTargetModel: "base"}); err != nil {
+		t.Fatalf("Unexpected scheduling error: %v", err)
+	}
+	// Decisions of other pools are not listed.
+	decisions.RecordDecision(&scheduling.Decision{Pool: "default/other"})
+
+	pools := map[types.NamespacedName]*Pool{
+		{Namespace: "default", Name: "pool"}: {Datastore: ds, Scheduler: scheduler},
+	}
+	return NewHandler(pools, decisions), ds
+}
+
+func serve(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return v
+}
+
+func TestPods(t *testing.T) {
+	h, _ := newTestHandler(t)
+	got := decode[[]PodInfo](t, serve(t, h, http.MethodGet, PodsPath, ""))
+	want := []PodInfo{
+		{
+			Name:                "default/pod-1",
+			Address:             "10.0.0.1",
+			InFlightRequests:    1,
+			WaitingQueueSize:    3,
+			KVCacheUsagePercent: 0.5,
+			MaxActiveModels:     4,
+		},
+		{
+			Name:         "default/pod-2",
+			Address:      "10.0.0.2",
+			MetricsStale: true,
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(PodInfo{}, "ActiveModels", "MetricsUpdateTime", "MetricsAge")); diff != "" {
+		t.Errorf("Unexpected pods (-want +got): %s", diff)
+	}
+	if got[0].MetricsUpdateTime == nil || got[0].MetricsAge == "" || got[1].MetricsUpdateTime != nil {
+		t.Errorf("Unexpected metrics freshness: %+v", got)
+	}
+}
+
+func TestModels(t *testing.T) {
+	h, _ := newTestHandler(t)
+	got := decode[[]ModelInfo](t, serve(t, h, http.MethodGet, ModelsPath, ""))
+	want := []ModelInfo{
+		{
+			Name:        "default/base",
+			ModelName:   "base",
+			Criticality: string(v1alpha1.Standard),
+			Targets:     []TargetInfo{{Name: "base", Weight: 1, Share: 1}},
+		},
+		{
+			Name:        "default/chat",
+			ModelName:   "chat",
+			Criticality: string(v1alpha1.Critical),
+			Targets: []TargetInfo{
+				{Name: "chat-v1", Weight: 30, Share: 0.75},
+				{Name: "chat-v2", Weight: 10, Share: 0.25},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected models (-want +got): %s", diff)
+	}
+}
+
+func TestPool(t *testing.T) {
+	h, _ := newTestHandler(t)
+	got := decode[PoolInfo](t, serve(t, h, http.MethodGet, PoolPath+"?pool=pool", ""))
+	if got.Name != "default/pool" || !got.Synced || got.Pool == nil || got.Pool.Spec.TargetPortNumber != 8000 {
+		t.Errorf("Unexpected pool: %+v", got)
+	}
+	want := SchedulerConfigInfo{
+		MetricsStalenessThreshold: scheduling.DefaultMetricsStalenessThreshold.String(),
+		StalenessPolicy:           scheduling.DefaultStalenessPolicy,
+		KVCacheThreshold:          scheduling.DefaultKVCacheThreshold,
+		QueueThresholdCritical:    scheduling.DefaultQueueThresholdCritical,
+		QueueingThresholdLoRA:     scheduling.DefaultQueueingThresholdLoRA,
+		Picker:                    scheduling.DefaultPicker,
+	}
+	if diff := cmp.Diff(want, got.SchedulerConfig); diff != "" {
+		t.Errorf("Unexpected scheduler config (-want +got): %s", diff)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	h, ds := newTestHandler(t)
+	got := decode[scheduling.Decision](t, serve(t, h, http.MethodPost, DryRunPath, `{"model": "chat", "prompt": "hello"}`))
+	if got.Request.Model != "chat" || !got.Request.Critical {
+		t.Errorf("Unexpected request: %+v", got.Request)
+	}
+	if got.Request.ResolvedTargetModel != "chat-v1" && got.Request.ResolvedTargetModel != "chat-v2" {
+		t.Errorf("Unexpected resolved target model %q", got.Request.ResolvedTargetModel)
+	}
+	if len(got.Steps) == 0 || got.Steps[0].Filter != "can serve model" {
+		t.Errorf("Unexpected filter steps: %+v", got.Steps)
+	}
+	if diff := cmp.Diff([]string{"default/pod-1"}, got.Candidates); diff != "" {
+		t.Errorf("Unexpected candidates (-want +got): %s", diff)
+	}
+	if got := ds.PodInFlightRequests(types.NamespacedName{Namespace: "default", Name: "pod-1"}); got != 1 {
+		t.Errorf("Expected the dry run not to track a request, got %d requests in flight", got)
+	}
+}
+
+func TestDecisions(t *testing.T) {
+	h, _ := newTestHandler(t)
+	got := decode[[]scheduling.Decision](t, serve(t, h, http.MethodGet, DecisionsPath, ""))
+	if len(got) != 1 {
+		t.Fatalf("Expected one decision, got %+v", got)
+	}
+	if got[0].Pool != "default/pool" || got[0].Request.Model != "base" || got[0].Target != "default/pod-1" {
+		t.Errorf("Unexpected decision: %+v", got[0])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	h, _ := newTestHandler(t)
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{name: "unknown pool", method: http.MethodGet, target: PodsPath + "?pool=other", wantStatus: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodPost, target: PodsPath, wantStatus: http.StatusMethodNotAllowed},
+		{name: "dry run by GET", method: http.MethodGet, target: DryRunPath, wantStatus: http.StatusMethodNotAllowed},
+		{name: "invalid body", method: http.MethodPost, target: DryRunPath, body: "{", wantStatus: http.StatusBadRequest},
+		{name: "missing model", method: http.MethodPost, target: DryRunPath, body: "{}", wantStatus: http.StatusBadRequest},
+		{name: "unknown model", method: http.MethodPost, target: DryRunPath, body: `{"model": "other"}`, wantStatus: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if rec := serve(t, h, test.method, test.target, test.body); rec.Code != test.wantStatus {
+				t.Errorf("Got status %d, want %d: %s", rec.Code, test.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWithBearerToken(t *testing.T) {
+	h := WithBearerToken(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}), "secret")
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, PodsPath, nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Authorization %q: got status %d, want %d", header, rec.Code, want)
+		}
+	}
+}
+
+func TestWithBearerTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	h, err := WithBearerTokenFile(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}), path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	check := func(token string, want int) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, PodsPath, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Token %q: got status %d, want %d", token, rec.Code, want)
+		}
+	}
+	check("old", http.StatusOK)
+
+	// The rotated token is used without recreating the handler.
+	if err := os.WriteFile(path, []byte("new\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	check("old", http.StatusUnauthorized)
+	check("new", http.StatusOK)
+
+	if _, err := WithBearerTokenFile(h, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing token file")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+const (
+	// PoolMetadataNamespace is the Envoy filter metadata namespace that may carry the pool a request
+	// is routed to.
+	PoolMetadataNamespace = "inference.networking.x-k8s.io"
+	// PoolMetadataKey is the key of the pool in the PoolMetadataNamespace filter metadata.
+	PoolMetadataKey = "pool"
+)
+
+// Pool holds the components serving a single InferencePool.
+type Pool struct {
+	Scheduler Scheduler
+	Datastore datastore.Datastore
+}
+
+// resolvePool returns the pool a request is routed to. The pool is selected by the pool selector
+// header, or else by the PoolMetadataKey filter metadata set on the Envoy route. The selector is
+// either "namespace/name" or a "name" that is unique across the served pools. If the EPP serves a
+// single pool, the selector is optional and ignored.
+func (s *Server) resolvePool(req *extProcPb.ProcessingRequest) (types.NamespacedName, *Pool, error) {
+	if len(s.pools) == 1 {
+		for name, pool := range s.pools {
+			return name, pool, nil
+		}
+	}
+
+	selector := s.poolSelector(req)
+	if selector == "" {
+		return types.NamespacedName{}, nil, errutil.Error{Code: errutil.BadRequest, Msg: fmt.Sprintf("no InferencePool selected by the %q header or %s/%s metadata", s.poolSelectorHeader, PoolMetadataNamespace, PoolMetadataKey)}
+	}
+	if namespace, name, found := strings.Cut(selector, "/"); found {
+		nn := types.NamespacedName{Namespace: namespace, Name: name}
+		if pool, ok := s.pools[nn]; ok {
+			return nn, pool, nil
+		}
+		return types.NamespacedName{}, nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("InferencePool %q is not served", selector)}
+	}
+
+	var (
+		found types.NamespacedName
+		pool  *Pool
+	)
+	for nn, p := range s.pools {
+		if nn.Name != selector {
+			continue
+		}
+		if pool != nil {
+			return types.NamespacedName{}, nil, errutil.Error{Code: errutil.BadRequest, Msg: fmt.Sprintf("InferencePool %q is ambiguous, use <namespace>/<name>", selector)}
+		}
+		found, pool = nn, p
+	}
+	if pool == nil {
+		return types.NamespacedName{}, nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("InferencePool %q is not served", selector)}
+	}
+	return found, pool, nil
+}
+
+// poolSelector returns the pool selected by the request headers or filter metadata, if any.
+func (s *Server) poolSelector(req *extProcPb.ProcessingRequest) string {
+	if h, ok := req.Request.(*extProcPb.ProcessingRequest_RequestHeaders); ok && s.poolSelectorHeader != "" {
+		for _, header := range h.RequestHeaders.GetHeaders().GetHeaders() {
+			if !strings.EqualFold(header.Key, s.poolSelectorHeader) {
+				continue
+			}
+			if len(header.RawValue) > 0 {
+				return string(header.RawValue)
+			}
+			return header.Value
+		}
+	}
+	if md, ok := req.GetMetadataContext().GetFilterMetadata()[PoolMetadataNamespace]; ok {
+		return md.GetFields()[PoolMetadataKey].GetStringValue()
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/types"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+func headersRequest(key, value string) *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_RequestHeaders{
+			RequestHeaders: &extProcPb.HttpHeaders{
+				Headers: &configPb.HeaderMap{
+					Headers: []*configPb.HeaderValue{{Key: key, RawValue: []byte(value)}},
+				},
+			},
+		},
+	}
+}
+
+func metadataRequest(value string) *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_RequestHeaders{RequestHeaders: &extProcPb.HttpHeaders{}},
+		MetadataContext: &configPb.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{
+				PoolMetadataNamespace: {
+					Fields: map[string]*structpb.Value{PoolMetadataKey: structpb.NewStringValue(value)},
+				},
+			},
+		},
+	}
+}
+
+func TestResolvePool(t *testing.T) {
+	a := types.NamespacedName{Namespace: "ns1", Name: "a"}
+	b1 := types.NamespacedName{Namespace: "ns1", Name: "b"}
+	b2 := types.NamespacedName{Namespace: "ns2", Name: "b"}
+	pools := map[types.NamespacedName]*Pool{a: {}, b1: {}, b2: {}}
+
+	tests := []struct {
+		name     string
+		pools    map[types.NamespacedName]*Pool
+		req      *extProcPb.ProcessingRequest
+		want     types.NamespacedName
+		wantCode string
+	}{
+		{
+			name:  "single pool without selector",
+			pools: map[types.NamespacedName]*Pool{a: {}},
+			req:   headersRequest("foo", "bar"),
+			want:  a,
+		},
+		{
+			name:  "namespaced name in header",
+			pools: pools,
+			req:   headersRequest("x-pool", "ns2/b"),
+			want:  b2,
+		},
+		{
+			name:  "unique name in header",
+			pools: pools,
+			req:   headersRequest("X-Pool", "a"),
+			want:  a,
+		},
+		{
+			name:  "metadata",
+			pools: pools,
+			req:   metadataRequest("ns1/b"),
+			want:  b1,
+		},
+		{
+			name:     "ambiguous name",
+			pools:    pools,
+			req:      headersRequest("x-pool", "b"),
+			wantCode: errutil.BadRequest,
+		},
+		{
+			name:     "no selector",
+			pools:    pools,
+			req:      headersRequest("foo", "bar"),
+			wantCode: errutil.BadRequest,
+		},
+		{
+			name:     "unknown pool",
+			pools:    pools,
+			req:      headersRequest("x-pool", "ns3/a"),
+			wantCode: errutil.BadConfiguration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewMultiPoolServer(test.pools, "target-pod", "x-pool")
+			got, pool, err := s.resolvePool(test.req)
+			if test.wantCode != "" {
+				if code := errutil.CanonicalCode(err); code != test.wantCode {
+					t.Fatalf("Unexpected error code, got %q, want %q (error: %v)", code, test.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != test.want || pool != test.pools[test.want] {
+				t.Errorf("Unexpected pool, got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectPoolName(t *testing.T) {
+	b1 := types.NamespacedName{Namespace: "ns1", Name: "b"}
+	b2 := types.NamespacedName{Namespace: "ns2", Name: "b"}
+	s := NewMultiPoolServer(map[types.NamespacedName]*Pool{b1: {}, b2: {}}, "target-pod", "x-pool")
+
+	// Pools with the same name in different namespaces must not share their request metrics.
+	for _, name := range []types.NamespacedName{b1, b2} {
+		reqCtx := &RequestContext{}
+		if err := s.selectPool(reqCtx, headersRequest("x-pool", name.String())); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := (types.NamespacedName{Namespace: reqCtx.PoolNamespace, Name: reqCtx.PoolName}); got != name {
+			t.Errorf("Unexpected pool, got %v, want %v", got, name)
+		}
+	}
+}
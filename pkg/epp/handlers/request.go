@@ -19,15 +19,21 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"strconv"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -44,45 +50,44 @@ func (s *Server) HandleRequestBody(
 	loggerVerbose := logger.V(logutil.VERBOSE)
 	loggerVerbose.Info("Handling request body")
 
+	if reqCtx.pool == nil {
+		// Envoy may be configured to skip the request headers.
+		if err := s.selectPool(reqCtx, req); err != nil {
+			return nil, err
+		}
+	}
+	pool := reqCtx.pool
+	// Envoy may be configured to skip the request headers, the span of the request is started
+	// without parent then.
+	reqCtx.startSpan(ctx, nil)
+	reqCtx.span.SetAttributes(tracing.PoolKey.String(types.NamespacedName{Namespace: reqCtx.PoolNamespace, Name: reqCtx.PoolName}.String()))
+
 	// Unmarshal request body (must be JSON).
 	v := req.Request.(*extProcPb.ProcessingRequest_RequestBody)
+	_, span := reqCtx.startChildSpan(ctx, parseRequestSpanName)
 	var rb map[string]interface{}
 	if err := json.Unmarshal(v.RequestBody.Body, &rb); err != nil {
 		logger.V(logutil.DEFAULT).Error(err, "Error unmarshaling request body")
-		return nil, errutil.Error{Code: errutil.BadRequest, Msg: fmt.Sprintf("error unmarshaling request body: %v", err)}
+		err = errutil.Error{Code: errutil.BadRequest, Msg: fmt.Sprintf("error unmarshaling request body: %v", err)}
+		endSpanWithError(span, err)
+		return nil, err
 	}
+	span.End()
 	loggerVerbose.Info("Request body unmarshalled", "body", rb)
 
-	// Resolve target models.
-	model, ok := rb["model"].(string)
-	if !ok {
-		return nil, errutil.Error{Code: errutil.BadRequest, Msg: "model not found in request"}
-	}
-	loggerVerbose.Info("Model requested", "model", model)
-	modelName := model
-
-	// NOTE: The nil checking for the modelObject means that we DO allow passthrough currently.
-	// This might be a security risk in the future where adapters not registered in the InferenceModel
-	// are able to be requested by using their distinct name.
-	modelObj, exist := s.datastore.ModelGet(model)
-	if !exist {
-		return nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("error finding a model object in InferenceModel for input %v", model)}
-	}
-	if len(modelObj.Spec.TargetModels) > 0 {
-		modelName = datastore.RandomWeightedDraw(logger, modelObj, 0)
-		if modelName == "" {
-			return nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("error getting target model name for model %v", modelObj.Name)}
-		}
-	}
-	llmReq := &scheduling.LLMRequest{
-		Model:               model,
-		ResolvedTargetModel: modelName,
-		Critical:            datastore.IsCritical(modelObj),
+	_, span = reqCtx.startChildSpan(ctx, resolveModelSpanName)
+	llmReq, err := NewLLMRequest(logger, pool.Datastore, rb)
+	if err != nil {
+		endSpanWithError(span, err)
+		return nil, err
 	}
+	modelAttrs := []attribute.KeyValue{tracing.ModelKey.String(llmReq.Model), tracing.TargetModelKey.String(llmReq.ResolvedTargetModel)}
+	span.SetAttributes(modelAttrs...)
+	span.End()
+	reqCtx.span.SetAttributes(modelAttrs...)
 	loggerVerbose.Info("LLM request assembled", "request", llmReq)
 
 	requestBody := v.RequestBody.Body
-	var err error
 	// Update target models in the body.
 	if llmReq.Model != llmReq.ResolvedTargetModel {
 		rb["model"] = llmReq.ResolvedTargetModel
@@ -94,27 +99,42 @@ func (s *Server) HandleRequestBody(
 		loggerVerbose.Info("Updated request body marshalled", "body", string(requestBody))
 	}
 
-	targetPod, err := s.scheduler.Schedule(ctx, llmReq)
+	_, span = reqCtx.startChildSpan(ctx, scheduleSpanName)
+	targetPod, decision, err := pool.Scheduler.ScheduleWithDecision(ctx, llmReq)
+	reqCtx.SchedulingDecision = decision
 	if err != nil {
-		return nil, errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: fmt.Errorf("failed to find target pod: %w", err).Error()}
+		// The scheduler rejects requests for a model no pod serves with BadConfiguration, all other
+		// failures mean that the pool has no capacity for the request.
+		code := errutil.InferencePoolResourceExhausted
+		var schedErr errutil.Error
+		if errors.As(err, &schedErr) && schedErr.Code == errutil.BadConfiguration {
+			code = schedErr.Code
+		}
+		err = errutil.Error{Code: code, Msg: fmt.Errorf("failed to find target pod: %w", err).Error()}
+		endSpanWithError(span, err)
+		return nil, err
 	}
+	span.SetAttributes(tracing.PodKey.String(targetPod.NamespacedName.String()))
+	span.End()
 
 	logger.V(logutil.DEFAULT).Info("Request handled",
 		"model", llmReq.Model, "targetModel", llmReq.ResolvedTargetModel, "endpoint", targetPod)
+	reqCtx.inFlightDone = pool.Datastore.PodTrackRequest(targetPod.NamespacedName)
 
 	// Insert target endpoint to instruct Envoy to route requests to the specified target pod.
 	// Attach the port number
-	pool, err := s.datastore.PoolGet()
+	poolObj, err := pool.Datastore.PoolGet()
 	if err != nil {
 		return nil, err
 	}
-	endpoint := targetPod.Address + ":" + strconv.Itoa(int(pool.Spec.TargetPortNumber))
+	endpoint := net.JoinHostPort(targetPod.Address, strconv.Itoa(int(poolObj.Spec.TargetPortNumber)))
 
 	reqCtx.Model = llmReq.Model
 	reqCtx.ResolvedTargetModel = llmReq.ResolvedTargetModel
 	reqCtx.RequestSize = len(v.RequestBody.Body)
 	reqCtx.TargetPod = targetPod.NamespacedName.String()
 	reqCtx.TargetEndpoint = endpoint
+	reqCtx.span.SetAttributes(tracing.PodKey.String(reqCtx.TargetPod), tracing.EndpointKey.String(endpoint))
 
 	headers := []*configPb.HeaderValueOption{
 		{
@@ -132,6 +152,8 @@ func (s *Server) HandleRequestBody(
 			},
 		},
 	}
+	// Propagate the trace context to the model server.
+	headers = append(headers, reqCtx.traceHeaders()...)
 	// Print headers for debugging
 	for _, header := range headers {
 		logger.V(logutil.DEBUG).Info("Request body header", "key", header.Header.Key, "value", header.Header.RawValue)
@@ -168,6 +190,38 @@ func (s *Server) HandleRequestBody(
 	return resp, nil
 }
 
+// NewLLMRequest returns the request to schedule for the given unmarshalled request body. The
+// requested model must be served by an InferenceModel of the pool, its target model is drawn
+// according to the weights of the target models.
+func NewLLMRequest(logger logr.Logger, ds datastore.Datastore, body map[string]interface{}) (*scheduling.LLMRequest, error) {
+	model, ok := body["model"].(string)
+	if !ok {
+		return nil, errutil.Error{Code: errutil.BadRequest, Msg: "model not found in request"}
+	}
+	logger.V(logutil.VERBOSE).Info("Model requested", "model", model)
+	modelName := model
+
+	// NOTE: The nil checking for the modelObject means that we DO allow passthrough currently.
+	// This might be a security risk in the future where adapters not registered in the InferenceModel
+	// are able to be requested by using their distinct name.
+	modelObj, exist := ds.ModelGet(model)
+	if !exist {
+		return nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("error finding a model object in InferenceModel for input %v", model)}
+	}
+	if len(modelObj.Spec.TargetModels) > 0 {
+		modelName = datastore.RandomWeightedDraw(logger, modelObj, 0)
+		if modelName == "" {
+			return nil, errutil.Error{Code: errutil.BadConfiguration, Msg: fmt.Sprintf("error getting target model name for model %v", modelObj.Name)}
+		}
+	}
+	return &scheduling.LLMRequest{
+		Model:               model,
+		ResolvedTargetModel: modelName,
+		Critical:            datastore.IsCritical(modelObj),
+		EstimatedTokens:     estimateRequestTokens(body),
+	}, nil
+}
+
 func HandleRequestHeaders(
 	ctx context.Context,
 	reqCtx *RequestContext,
@@ -177,6 +231,9 @@ func HandleRequestHeaders(
 	h := r.(*extProcPb.ProcessingRequest_RequestHeaders)
 	log.FromContext(ctx).V(logutil.VERBOSE).Info("Handling request headers", "headers", h)
 
+	// Continue the trace of the request, if propagated by the client or by Envoy.
+	reqCtx.startSpan(ctx, h.RequestHeaders.GetHeaders().GetHeaders())
+
 	resp := &extProcPb.ProcessingResponse{
 		Response: &extProcPb.ProcessingResponse_RequestHeaders{
 			RequestHeaders: &extProcPb.HeadersResponse{
@@ -38,6 +38,8 @@ func (s *Server) HandleResponseHeaders(
 	loggerVerbose.Info("Processing ResponseHeaders")
 	h := req.Request.(*extProcPb.ProcessingRequest_ResponseHeaders)
 	loggerVerbose.Info("Headers before", "headers", h)
+	_, span := reqCtx.startChildSpan(ctx, responseHeadersSpanName)
+	defer span.End()
 
 	// Example header
 	// {
@@ -134,11 +136,15 @@ func (s *Server) HandleResponseBody(
 	loggerVerbose := logger.V(logutil.VERBOSE)
 	loggerVerbose.Info("Processing HandleResponseBody")
 	body := req.Request.(*extProcPb.ProcessingRequest_ResponseBody)
+	_, span := reqCtx.startChildSpan(ctx, responseBodySpanName)
 
 	res := Response{}
 	if err := json.Unmarshal(body.ResponseBody.Body, &res); err != nil {
-		return nil, errutil.Error{Code: errutil.Internal, Msg: fmt.Sprintf("unmarshaling response body: %v", err)}
+		err = errutil.Error{Code: errutil.Internal, Msg: fmt.Sprintf("unmarshaling response body: %v", err)}
+		endSpanWithError(span, err)
+		return nil, err
 	}
+	span.End()
 	reqCtx.Response = res
 	reqCtx.ResponseSize = len(body.ResponseBody.Body)
 	// ResponseComplete is to indicate the response is complete. In non-streaming
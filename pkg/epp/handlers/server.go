@@ -20,12 +20,15 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync/atomic"
 	"time"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
@@ -36,24 +39,46 @@ import (
 
 func NewServer(scheduler Scheduler, targetEndpointKey string, datastore datastore.Datastore) *Server {
 	return &Server{
-		scheduler:         scheduler,
 		targetEndpointKey: targetEndpointKey,
-		datastore:         datastore,
+		pools: map[types.NamespacedName]*Pool{
+			// The pool name is resolved from the datastore when a request is handled.
+			{}: {Scheduler: scheduler, Datastore: datastore},
+		},
+	}
+}
+
+// NewMultiPoolServer returns a server that routes each request to one of the given pools, selected
+// by the poolSelectorHeader request header or by the pool Envoy filter metadata.
+func NewMultiPoolServer(pools map[types.NamespacedName]*Pool, targetEndpointKey, poolSelectorHeader string) *Server {
+	return &Server{
+		targetEndpointKey:  targetEndpointKey,
+		poolSelectorHeader: poolSelectorHeader,
+		pools:              pools,
 	}
 }
 
 // Server implements the Envoy external processing server.
 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/service/ext_proc/v3/external_processor.proto
 type Server struct {
-	scheduler Scheduler
 	// The key of the header to specify the target pod address. This value needs to match Envoy
 	// configuration.
 	targetEndpointKey string
-	datastore         datastore.Datastore
+	// The key of the header to select the pool of a request, if the server serves multiple pools.
+	poolSelectorHeader string
+	pools              map[types.NamespacedName]*Pool
+	// activeStreams is the number of ext_proc streams being processed.
+	activeStreams atomic.Int64
+}
+
+// ActiveStreams returns the number of ext_proc streams being processed.
+func (s *Server) ActiveStreams() int {
+	return int(s.activeStreams.Load())
 }
 
 type Scheduler interface {
-	Schedule(ctx context.Context, b *scheduling.LLMRequest) (targetPod datastore.PodMetrics, err error)
+	// ScheduleWithDecision returns the target pod of the request, and the decision trace explaining
+	// the choice.
+	ScheduleWithDecision(ctx context.Context, b *scheduling.LLMRequest) (targetPod datastore.PodMetrics, decision *scheduling.Decision, err error)
 }
 
 func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
@@ -61,10 +86,15 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 	logger := log.FromContext(ctx)
 	loggerVerbose := logger.V(logutil.VERBOSE)
 	loggerVerbose.Info("Processing")
+	s.activeStreams.Add(1)
+	defer s.activeStreams.Add(-1)
 
 	// Create request context to share states during life time of an HTTP request.
 	// See https://github.com/envoyproxy/envoy/issues/17540.
 	reqCtx := &RequestContext{}
+	// The request is no longer in flight once the stream ends, even if the response was not
+	// complete.
+	defer reqCtx.completeInFlight()
 
 	// Create variable for error handling as each request should only report once for
 	// error metric. This doesn't cover the error "Cannot receive stream request" because
@@ -72,11 +102,12 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 	var err error
 	defer func(error) {
 		if reqCtx.ResponseStatusCode != "" {
-			metrics.RecordRequestErrCounter(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseStatusCode)
+			metrics.RecordRequestErrCounter(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseStatusCode)
 		} else if err != nil {
-			metrics.RecordRequestErrCounter(reqCtx.Model, reqCtx.ResolvedTargetModel, errutil.CanonicalCode(err))
+			metrics.RecordRequestErrCounter(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, errutil.CanonicalCode(err))
 		}
 	}(err)
+	defer func() { reqCtx.endSpan(err) }()
 
 	for {
 		select {
@@ -100,13 +131,14 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 		switch v := req.Request.(type) {
 		case *extProcPb.ProcessingRequest_RequestHeaders:
 			reqCtx.RequestReceivedTimestamp = time.Now()
+			err = s.selectPool(reqCtx, req)
 			resp = HandleRequestHeaders(ctx, reqCtx, req)
 			loggerVerbose.Info("Request context after HandleRequestHeaders", "context", reqCtx)
 		case *extProcPb.ProcessingRequest_RequestBody:
 			resp, err = s.HandleRequestBody(ctx, reqCtx, req)
 			if err == nil {
-				metrics.RecordRequestCounter(reqCtx.Model, reqCtx.ResolvedTargetModel)
-				metrics.RecordRequestSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestSize)
+				metrics.RecordRequestCounter(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel)
+				metrics.RecordRequestSizes(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestSize)
 			}
 			loggerVerbose.Info("Request context after HandleRequestBody", "context", reqCtx)
 		case *extProcPb.ProcessingRequest_ResponseHeaders:
@@ -115,11 +147,12 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 		case *extProcPb.ProcessingRequest_ResponseBody:
 			resp, err = s.HandleResponseBody(ctx, reqCtx, req)
 			if err == nil && reqCtx.ResponseComplete {
+				reqCtx.completeInFlight()
 				reqCtx.ResponseCompleteTimestamp = time.Now()
-				metrics.RecordRequestLatencies(ctx, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestReceivedTimestamp, reqCtx.ResponseCompleteTimestamp)
-				metrics.RecordResponseSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseSize)
-				metrics.RecordInputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Response.Usage.PromptTokens)
-				metrics.RecordOutputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Response.Usage.CompletionTokens)
+				metrics.RecordRequestLatencies(ctx, reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestReceivedTimestamp, reqCtx.ResponseCompleteTimestamp)
+				metrics.RecordResponseSizes(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseSize)
+				metrics.RecordInputTokens(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Response.Usage.PromptTokens)
+				metrics.RecordOutputTokens(reqCtx.PoolNamespace, reqCtx.PoolName, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Response.Usage.CompletionTokens)
 			}
 			loggerVerbose.Info("Request context after HandleResponseBody", "context", reqCtx)
 		default:
@@ -187,8 +220,31 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 	}
 }
 
+// selectPool resolves the pool of the request and stores it in the request context.
+func (s *Server) selectPool(reqCtx *RequestContext, req *extProcPb.ProcessingRequest) error {
+	name, pool, err := s.resolvePool(req)
+	if err != nil {
+		return err
+	}
+	if name == (types.NamespacedName{}) {
+		if p, err := pool.Datastore.PoolGet(); err == nil {
+			name = types.NamespacedName{Namespace: p.Namespace, Name: p.Name}
+		}
+	}
+	reqCtx.pool = pool
+	reqCtx.PoolNamespace = name.Namespace
+	reqCtx.PoolName = name.Name
+	return nil
+}
+
 // RequestContext stores context information during the life time of an HTTP request.
 type RequestContext struct {
+	// PoolNamespace and PoolName identify the InferencePool the request is routed to.
+	PoolNamespace string
+	PoolName      string
+	pool          *Pool
+	// inFlightDone marks the request as no longer in flight on the target pod.
+	inFlightDone              func()
 	TargetPod                 string
 	TargetEndpoint            string
 	Model                     string
@@ -200,4 +256,16 @@ type RequestContext struct {
 	ResponseSize              int
 	ResponseComplete          bool
 	ResponseStatusCode        string
+	// SchedulingDecision is the decision trace of the scheduler for the request.
+	SchedulingDecision *scheduling.Decision
+	// span is the tracing span of the request, traceCtx is the context carrying it.
+	span     trace.Span
+	traceCtx context.Context
+}
+
+func (r *RequestContext) completeInFlight() {
+	if r.inFlightDone != nil {
+		r.inFlightDone()
+		r.inFlightDone = nil
+	}
 }
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+// charsPerToken is a rough average of characters per token for English text with common
+// tokenizers. It is only used to estimate the KV cache demand of a request, no tokenizer is run.
+const charsPerToken = 4
+
+// estimateRequestTokens estimates the number of KV cache tokens a completions or chat completions
+// request needs: its prompt tokens plus the maximum number of tokens to generate. If the request
+// does not set max_tokens or max_completion_tokens, only the prompt tokens are counted.
+func estimateRequestTokens(rb map[string]interface{}) int {
+	chars := promptChars(rb["prompt"])
+	if messages, ok := rb["messages"].([]interface{}); ok {
+		for _, m := range messages {
+			if message, ok := m.(map[string]interface{}); ok {
+				chars += promptChars(message["content"])
+			}
+		}
+	}
+	tokens := (chars + charsPerToken - 1) / charsPerToken
+
+	for _, key := range []string{"max_completion_tokens", "max_tokens"} {
+		if maxTokens, ok := rb[key].(float64); ok && maxTokens > 0 {
+			tokens += int(maxTokens)
+			break
+		}
+	}
+	return tokens
+}
+
+// promptChars returns the number of characters in a prompt or message content, which is either a
+// string, a list of strings or a list of content parts.
+func promptChars(v interface{}) int {
+	switch p := v.(type) {
+	case string:
+		return len(p)
+	case []interface{}:
+		chars := 0
+		for _, e := range p {
+			switch part := e.(type) {
+			case string:
+				chars += len(part)
+			case map[string]interface{}:
+				if text, ok := part["text"].(string); ok {
+					chars += len(text)
+				}
+			}
+		}
+		return chars
+	}
+	return 0
+}
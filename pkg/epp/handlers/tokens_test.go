@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEstimateRequestTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "completions",
+			body: `{"model": "m", "prompt": "0123456789abcdef", "max_tokens": 100}`,
+			want: 104,
+		},
+		{
+			name: "completions without max tokens",
+			body: `{"model": "m", "prompt": "0123456789"}`,
+			want: 3,
+		},
+		{
+			name: "completions with batched prompt",
+			body: `{"model": "m", "prompt": ["01234567", "89abcdef"], "max_tokens": 10}`,
+			want: 14,
+		},
+		{
+			name: "chat completions",
+			body: `{"model": "m", "max_completion_tokens": 50, "max_tokens": 1000, "messages": [
+				{"role": "system", "content": "01234567"},
+				{"role": "user", "content": [{"type": "text", "text": "89abcdef"}, {"type": "image_url", "image_url": {"url": "x"}}]}
+			]}`,
+			want: 54,
+		},
+		{
+			name: "no prompt",
+			body: `{"model": "m"}`,
+			want: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var rb map[string]interface{}
+			if err := json.Unmarshal([]byte(test.body), &rb); err != nil {
+				t.Fatal(err)
+			}
+			if got := estimateRequestTokens(rb); got != test.want {
+				t.Errorf("estimateRequestTokens() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
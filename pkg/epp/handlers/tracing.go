@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
+)
+
+// Names of the spans of a request.
+const (
+	requestSpanName         = "epp.request"
+	parseRequestSpanName    = "epp.parse_request"
+	resolveModelSpanName    = "epp.resolve_model"
+	scheduleSpanName        = "epp.schedule"
+	responseHeadersSpanName = "epp.handle_response_headers"
+	responseBodySpanName    = "epp.handle_response_body"
+)
+
+// startSpan starts the span of the request, as a child of the trace context propagated in the
+// given request headers, if any. It is a no-op if the span is already started.
+func (r *RequestContext) startSpan(ctx context.Context, headers []*configPb.HeaderValue) {
+	if r.span != nil {
+		return
+	}
+	carrier := tracing.HeaderCarrier(headers)
+	ctx = tracing.Propagator.Extract(ctx, &carrier)
+	r.traceCtx, r.span = tracing.Tracer().Start(ctx, requestSpanName, trace.WithSpanKind(trace.SpanKindServer))
+}
+
+// startChildSpan starts a span of a processing step of the request.
+func (r *RequestContext) startChildSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	r.startSpan(ctx, nil)
+	return tracing.Tracer().Start(r.traceCtx, name)
+}
+
+// endSpan ends the span of the request, recording the given processing error.
+func (r *RequestContext) endSpan(err error) {
+	if r.span == nil {
+		return
+	}
+	if r.ResponseStatusCode != "" {
+		r.span.SetStatus(codes.Error, r.ResponseStatusCode)
+	} else if err != nil {
+		endSpanWithError(r.span, err)
+		return
+	}
+	r.span.End()
+}
+
+// endSpanWithError records the error of a span, and ends it.
+func endSpanWithError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+}
+
+// traceHeaders returns the headers propagating the trace context of the request to the model
+// server.
+func (r *RequestContext) traceHeaders() []*configPb.HeaderValueOption {
+	var carrier tracing.HeaderCarrier
+	tracing.Propagator.Inject(r.traceCtx, &carrier)
+	headers := make([]*configPb.HeaderValueOption, 0, len(carrier))
+	for _, h := range carrier {
+		headers = append(headers, &configPb.HeaderValueOption{Header: h})
+	}
+	return headers
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentID    = "00f067aa0ba902b7"
+	testTraceparent = "00-" + testTraceID + "-" + testParentID + "-01"
+)
+
+type fakeScheduler struct {
+	pod datastore.PodMetrics
+	err error
+}
+
+func (s *fakeScheduler) ScheduleWithDecision(context.Context, *scheduling.LLMRequest) (datastore.PodMetrics, *scheduling.Decision, error) {
+	return s.pod, nil, s.err
+}
+
+// setupTestTracing installs a tracer provider recording the spans in memory for the duration of
+// the test.
+func setupTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewTracerProvider(sdktrace.NewSimpleSpanProcessor(exporter), 1))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return exporter
+}
+
+func TestRequestTracing(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	pod := types.NamespacedName{Namespace: "default", Name: "pod-1"}
+
+	tests := []struct {
+		name         string
+		traceparent  string
+		schedulerErr error
+		// wantSpans are the names of the spans ended, in order.
+		wantSpans      []string
+		wantAttributes []attribute.KeyValue
+		wantError      bool
+	}{
+		{
+			name:        "propagated trace",
+			traceparent: testTraceparent,
+			wantSpans: []string{
+				parseRequestSpanName, resolveModelSpanName, scheduleSpanName, responseBodySpanName, requestSpanName,
+			},
+			wantAttributes: []attribute.KeyValue{
+				tracing.PoolKey.String("default/pool"),
+				tracing.ModelKey.String("chat"),
+				tracing.TargetModelKey.String("chat-v1"),
+				tracing.PodKey.String(pod.String()),
+				tracing.EndpointKey.String("10.0.0.1:8000"),
+			},
+		},
+		{
+			name: "new trace",
+			wantSpans: []string{
+				parseRequestSpanName, resolveModelSpanName, scheduleSpanName, responseBodySpanName, requestSpanName,
+			},
+			wantAttributes: []attribute.KeyValue{
+				tracing.PoolKey.String("default/pool"),
+				tracing.ModelKey.String("chat"),
+				tracing.TargetModelKey.String("chat-v1"),
+				tracing.PodKey.String(pod.String()),
+				tracing.EndpointKey.String("10.0.0.1:8000"),
+			},
+		},
+		{
+			name:         "scheduling failure",
+			traceparent:  testTraceparent,
+			schedulerErr: errors.New("no capacity"),
+			wantSpans:    []string{parseRequestSpanName, resolveModelSpanName, scheduleSpanName, requestSpanName},
+			wantAttributes: []attribute.KeyValue{
+				tracing.PoolKey.String("default/pool"),
+				tracing.ModelKey.String("chat"),
+				tracing.TargetModelKey.String("chat-v1"),
+			},
+			wantError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exporter := setupTestTracing(t)
+			ds := datastore.NewDatastore()
+			ds.PoolSet(&v1alpha1.InferencePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+			})
+			ds.ModelSet(&v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "default"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName:    "chat",
+					TargetModels: []v1alpha1.TargetModel{{Name: "chat-v1"}},
+				},
+			})
+			ds.PodUpdateOrAddEndpointIfNotExist(pod, "10.0.0.1", false)
+			s := NewServer(&fakeScheduler{
+				pod: datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: pod, Address: "10.0.0.1"}},
+				err: test.schedulerErr,
+			}, "target-pod", ds)
+
+			reqCtx := &RequestContext{}
+			headersReq := headersRequest(":path", "/v1/completions")
+			if test.traceparent != "" {
+				headersReq = headersRequest("traceparent", test.traceparent)
+			}
+			if err := s.selectPool(reqCtx, headersReq); err != nil {
+				t.Fatalf("Unexpected error selecting the pool: %v", err)
+			}
+			HandleRequestHeaders(ctx, reqCtx, headersReq)
+			resp, err := s.HandleRequestBody(ctx, reqCtx, &extProcPb.ProcessingRequest{
+				Request: &extProcPb.ProcessingRequest_RequestBody{
+					RequestBody: &extProcPb.HttpBody{Body: []byte(`{"model": "chat", "prompt": "hello"}`)},
+				},
+			})
+			if (err != nil) != test.wantError {
+				t.Fatalf("Unexpected error handling the request body, got %v, want error %v", err, test.wantError)
+			}
+			if err == nil {
+				if _, err := s.HandleResponseBody(ctx, reqCtx, &extProcPb.ProcessingRequest{
+					Request: &extProcPb.ProcessingRequest_ResponseBody{ResponseBody: &extProcPb.HttpBody{Body: []byte(body)}},
+				}); err != nil {
+					t.Fatalf("Unexpected error handling the response body: %v", err)
+				}
+			}
+			reqCtx.endSpan(err)
+
+			spans := exporter.GetSpans()
+			var names []string
+			for _, span := range spans {
+				names = append(names, span.Name)
+			}
+			if diff := cmp.Diff(test.wantSpans, names); diff != "" {
+				t.Fatalf("Unexpected spans (-want +got): %s", diff)
+			}
+			root := spans[len(spans)-1]
+			for _, span := range spans[:len(spans)-1] {
+				if span.Parent.SpanID() != root.SpanContext.SpanID() {
+					t.Errorf("Span %q is not a child of the request span", span.Name)
+				}
+			}
+			if test.traceparent != "" {
+				if got := root.Parent.TraceID().String(); got != testTraceID {
+					t.Errorf("Unexpected trace ID of the request span, got %s, want %s", got, testTraceID)
+				}
+				if got := root.Parent.SpanID().String(); got != testParentID {
+					t.Errorf("Unexpected parent of the request span, got %s, want %s", got, testParentID)
+				}
+			} else if root.Parent.IsValid() {
+				t.Errorf("Unexpected parent of the request span %v", root.Parent)
+			}
+			if root.SpanKind != trace.SpanKindServer {
+				t.Errorf("Unexpected kind of the request span %v", root.SpanKind)
+			}
+			if diff := cmp.Diff(test.wantAttributes, root.Attributes, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("Unexpected attributes of the request span (-want +got): %s", diff)
+			}
+			if got := root.Status.Code == codes.Error; got != test.wantError {
+				t.Errorf("Unexpected status of the request span %v", root.Status)
+			}
+			if test.wantError {
+				return
+			}
+
+			// The trace context is propagated to the model server, as a child of the request span.
+			want := "00-" + root.SpanContext.TraceID().String() + "-" + root.SpanContext.SpanID().String() + "-01"
+			if got := headerValue(resp.GetRequestBody().GetResponse().GetHeaderMutation().GetSetHeaders(), "traceparent"); got != want {
+				t.Errorf("Unexpected traceparent header, got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRequestTracingDisabled(t *testing.T) {
+	// Without tracer provider, the trace context is propagated unchanged to the model server.
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	reqCtx := &RequestContext{}
+	reqCtx.startSpan(context.Background(), []*configPb.HeaderValue{{Key: "traceparent", RawValue: []byte(testTraceparent)}})
+	if got := headerValue(reqCtx.traceHeaders(), "traceparent"); got != testTraceparent {
+		t.Errorf("Unexpected traceparent header, got %q, want %q", got, testTraceparent)
+	}
+}
+
+func headerValue(headers []*configPb.HeaderValueOption, key string) string {
+	for _, h := range headers {
+		if h.Header.Key == key {
+			return string(h.Header.RawValue)
+		}
+	}
+	return ""
+}
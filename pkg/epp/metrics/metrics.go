@@ -30,6 +30,8 @@ import (
 const (
 	InferenceModelComponent = "inference_model"
 	InferencePoolComponent  = "inference_pool"
+	// InferenceExtensionComponent is the subsystem of the metrics of the EPP itself.
+	InferenceExtensionComponent = "inference_extension"
 )
 
 var (
@@ -38,41 +40,41 @@ var (
 		&compbasemetrics.CounterOpts{
 			Subsystem:      InferenceModelComponent,
 			Name:           "request_total",
-			Help:           "Counter of inference model requests broken out for each model and target model.",
+			Help:           "Counter of inference model requests broken out for each pool, model and target model.",
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	requestErrCounter = compbasemetrics.NewCounterVec(
 		&compbasemetrics.CounterOpts{
 			Subsystem:      InferenceModelComponent,
 			Name:           "request_error_total",
-			Help:           "Counter of inference model requests errors broken out for each model and target model.",
+			Help:           "Counter of inference model requests errors broken out for each pool, model and target model.",
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name", "error_code"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name", "error_code"},
 	)
 
 	requestLatencies = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Subsystem: InferenceModelComponent,
 			Name:      "request_duration_seconds",
-			Help:      "Inference model response latency distribution in seconds for each model and target model.",
+			Help:      "Inference model response latency distribution in seconds for each pool, model and target model.",
 			Buckets: []float64{
 				0.005, 0.025, 0.05, 0.1, 0.2, 0.4, 0.6, 0.8, 1.0, 1.25, 1.5, 2, 3,
 				4, 5, 6, 8, 10, 15, 20, 30, 45, 60, 120, 180, 240, 300, 360, 480, 600, 900, 1200, 1800, 2700, 3600,
 			},
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	requestSizes = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Subsystem: InferenceModelComponent,
 			Name:      "request_sizes",
-			Help:      "Inference model requests size distribution in bytes for each model and target model.",
+			Help:      "Inference model requests size distribution in bytes for each pool, model and target model.",
 			// Use buckets ranging from 1000 bytes (1KB) to 10^9 bytes (1GB).
 			Buckets: []float64{
 				64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536, // More fine-grained up to 64KB
@@ -81,44 +83,44 @@ var (
 			},
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	responseSizes = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Subsystem: InferenceModelComponent,
 			Name:      "response_sizes",
-			Help:      "Inference model responses size distribution in bytes for each model and target model.",
+			Help:      "Inference model responses size distribution in bytes for each pool, model and target model.",
 			// Most models have a response token < 8192 tokens. Each token, in average, has 4 characters.
 			// 8192 * 4 = 32768.
 			Buckets:        []float64{1, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32778, 65536},
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	inputTokens = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Subsystem: InferenceModelComponent,
 			Name:      "input_tokens",
-			Help:      "Inference model input token count distribution for requests in each model.",
+			Help:      "Inference model input token count distribution for requests in each pool and model.",
 			// Most models have a input context window less than 1 million tokens.
 			Buckets:        []float64{1, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32778, 65536, 131072, 262144, 524288, 1048576},
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	outputTokens = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Subsystem: InferenceModelComponent,
 			Name:      "output_tokens",
-			Help:      "Inference model output token count distribution for requests in each model.",
+			Help:      "Inference model output token count distribution for requests in each pool and model.",
 			// Most models generates output less than 8192 tokens.
 			Buckets:        []float64{1, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192},
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"model_name", "target_model_name"},
+		[]string{"pool_namespace", "pool_name", "model_name", "target_model_name"},
 	)
 
 	// Inference Pool Metrics
@@ -129,7 +131,7 @@ var (
 			Help:           "The average kv cache utilization for an inference server pool.",
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"name"},
+		[]string{"namespace", "name"},
 	)
 
 	inferencePoolAvgQueueSize = compbasemetrics.NewGaugeVec(
@@ -139,7 +141,76 @@ var (
 			Help:           "The average number of requests pending in the model server queue.",
 			StabilityLevel: compbasemetrics.ALPHA,
 		},
-		[]string{"name"},
+		[]string{"namespace", "name"},
+	)
+
+	inferencePoolStalePods = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Subsystem:      InferencePoolComponent,
+			Name:           "stale_pods",
+			Help:           "The number of pods in an inference server pool whose metrics were not refreshed within the staleness threshold.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"namespace", "name"},
+	)
+
+	inferencePoolDrainingPods = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Subsystem:      InferencePoolComponent,
+			Name:           "draining_pods",
+			Help:           "The number of draining pods in an inference server pool, which do not get new requests.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"namespace", "name"},
+	)
+
+	inferencePoolDrainingInFlightRequests = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Subsystem:      InferencePoolComponent,
+			Name:           "draining_in_flight_requests",
+			Help:           "The number of requests in flight on the draining pods of an inference server pool.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"namespace", "name"},
+	)
+
+	inferencePoolScrapeFailures = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Subsystem:      InferencePoolComponent,
+			Name:           "metrics_scrape_failures_total",
+			Help:           "Counter of failed model server metrics scrapes for an inference server pool.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// Inference Extension Metrics
+	configReloads = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Subsystem:      InferenceExtensionComponent,
+			Name:           "config_reloads_total",
+			Help:           "Counter of config file reloads broken out for each result: success, failure or restart_required.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	schedulingDecisionsDropped = compbasemetrics.NewCounter(
+		&compbasemetrics.CounterOpts{
+			Subsystem:      InferenceExtensionComponent,
+			Name:           "scheduling_decisions_dropped_total",
+			Help:           "Counter of scheduling decisions not written to the decision file because its buffer was full.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+
+	shutdownCutOffStreams = compbasemetrics.NewCounter(
+		&compbasemetrics.CounterOpts{
+			Subsystem:      InferenceExtensionComponent,
+			Name:           "shutdown_cut_off_streams_total",
+			Help:           "Counter of ext_proc streams cancelled because they did not complete within the shutdown timeout.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
 	)
 )
 
@@ -158,61 +229,100 @@ func Register() {
 
 		legacyregistry.MustRegister(inferencePoolAvgKVCache)
 		legacyregistry.MustRegister(inferencePoolAvgQueueSize)
+		legacyregistry.MustRegister(inferencePoolStalePods)
+		legacyregistry.MustRegister(inferencePoolDrainingPods)
+		legacyregistry.MustRegister(inferencePoolDrainingInFlightRequests)
+		legacyregistry.MustRegister(inferencePoolScrapeFailures)
+
+		legacyregistry.MustRegister(configReloads)
+		legacyregistry.MustRegister(schedulingDecisionsDropped)
+		legacyregistry.MustRegister(shutdownCutOffStreams)
 	})
 }
 
 // RecordRequstCounter records the number of requests.
-func RecordRequestCounter(modelName, targetModelName string) {
-	requestCounter.WithLabelValues(modelName, targetModelName).Inc()
+func RecordRequestCounter(poolNamespace, poolName, modelName, targetModelName string) {
+	requestCounter.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Inc()
 }
 
 // RecordRequestErrCounter records the number of error requests.
-func RecordRequestErrCounter(modelName, targetModelName string, code string) {
+func RecordRequestErrCounter(poolNamespace, poolName, modelName, targetModelName string, code string) {
 	if code != "" {
-		requestErrCounter.WithLabelValues(modelName, targetModelName, code).Inc()
+		requestErrCounter.WithLabelValues(poolNamespace, poolName, modelName, targetModelName, code).Inc()
 	}
 }
 
 // RecordRequestSizes records the request sizes.
-func RecordRequestSizes(modelName, targetModelName string, reqSize int) {
-	requestSizes.WithLabelValues(modelName, targetModelName).Observe(float64(reqSize))
+func RecordRequestSizes(poolNamespace, poolName, modelName, targetModelName string, reqSize int) {
+	requestSizes.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Observe(float64(reqSize))
 }
 
 // RecordRequestLatencies records duration of request.
-func RecordRequestLatencies(ctx context.Context, modelName, targetModelName string, received time.Time, complete time.Time) bool {
+func RecordRequestLatencies(ctx context.Context, poolNamespace, poolName, modelName, targetModelName string, received time.Time, complete time.Time) bool {
 	if !complete.After(received) {
 		log.FromContext(ctx).V(logutil.DEFAULT).Error(nil, "Request latency values are invalid",
-			"modelName", modelName, "targetModelName", targetModelName, "completeTime", complete, "receivedTime", received)
+			"poolNamespace", poolNamespace, "poolName", poolName, "modelName", modelName, "targetModelName", targetModelName, "completeTime", complete, "receivedTime", received)
 		return false
 	}
 	elapsedSeconds := complete.Sub(received).Seconds()
-	requestLatencies.WithLabelValues(modelName, targetModelName).Observe(elapsedSeconds)
+	requestLatencies.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Observe(elapsedSeconds)
 	return true
 }
 
 // RecordResponseSizes records the response sizes.
-func RecordResponseSizes(modelName, targetModelName string, size int) {
-	responseSizes.WithLabelValues(modelName, targetModelName).Observe(float64(size))
+func RecordResponseSizes(poolNamespace, poolName, modelName, targetModelName string, size int) {
+	responseSizes.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Observe(float64(size))
 }
 
 // RecordInputTokens records input tokens count.
-func RecordInputTokens(modelName, targetModelName string, size int) {
+func RecordInputTokens(poolNamespace, poolName, modelName, targetModelName string, size int) {
 	if size > 0 {
-		inputTokens.WithLabelValues(modelName, targetModelName).Observe(float64(size))
+		inputTokens.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Observe(float64(size))
 	}
 }
 
 // RecordOutputTokens records output tokens count.
-func RecordOutputTokens(modelName, targetModelName string, size int) {
+func RecordOutputTokens(poolNamespace, poolName, modelName, targetModelName string, size int) {
 	if size > 0 {
-		outputTokens.WithLabelValues(modelName, targetModelName).Observe(float64(size))
+		outputTokens.WithLabelValues(poolNamespace, poolName, modelName, targetModelName).Observe(float64(size))
 	}
 }
 
-func RecordInferencePoolAvgKVCache(name string, utilization float64) {
-	inferencePoolAvgKVCache.WithLabelValues(name).Set(utilization)
+func RecordInferencePoolAvgKVCache(namespace, name string, utilization float64) {
+	inferencePoolAvgKVCache.WithLabelValues(namespace, name).Set(utilization)
+}
+
+func RecordInferencePoolAvgQueueSize(namespace, name string, queueSize float64) {
+	inferencePoolAvgQueueSize.WithLabelValues(namespace, name).Set(queueSize)
+}
+
+// RecordInferencePoolStalePods records the number of pods with stale metrics.
+func RecordInferencePoolStalePods(namespace, name string, count int) {
+	inferencePoolStalePods.WithLabelValues(namespace, name).Set(float64(count))
+}
+
+// RecordInferencePoolDrainingPods records the number of draining pods and their in-flight requests.
+func RecordInferencePoolDrainingPods(namespace, name string, count int, inFlightRequests int) {
+	inferencePoolDrainingPods.WithLabelValues(namespace, name).Set(float64(count))
+	inferencePoolDrainingInFlightRequests.WithLabelValues(namespace, name).Set(float64(inFlightRequests))
+}
+
+// RecordInferencePoolScrapeFailure records a failed model server metrics scrape.
+func RecordInferencePoolScrapeFailure(namespace, name string) {
+	inferencePoolScrapeFailures.WithLabelValues(namespace, name).Inc()
+}
+
+// RecordConfigReload records a reload of the config file with its result.
+func RecordConfigReload(result string) {
+	configReloads.WithLabelValues(result).Inc()
+}
+
+// RecordSchedulingDecisionDropped records a scheduling decision dropped by the decision file.
+func RecordSchedulingDecisionDropped() {
+	schedulingDecisionsDropped.Inc()
 }
 
-func RecordInferencePoolAvgQueueSize(name string, queueSize float64) {
-	inferencePoolAvgQueueSize.WithLabelValues(name).Set(queueSize)
+// RecordShutdownCutOffStreams records the ext_proc streams cancelled on shutdown.
+func RecordShutdownCutOffStreams(count int) {
+	shutdownCutOffStreams.Add(float64(count))
 }
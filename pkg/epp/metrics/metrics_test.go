@@ -38,10 +38,19 @@ const (
 	OutputTokensMetric      = InferenceModelComponent + "_output_tokens"
 	KVCacheAvgUsageMetric   = InferencePoolComponent + "_average_kv_cache_utilization"
 	QueueAvgSizeMetric      = InferencePoolComponent + "_average_queue_size"
+	StalePodsMetric         = InferencePoolComponent + "_stale_pods"
+	DrainingPodsMetric      = InferencePoolComponent + "_draining_pods"
+	DrainingInFlightMetric  = InferencePoolComponent + "_draining_in_flight_requests"
+	ScrapeFailuresMetric    = InferencePoolComponent + "_metrics_scrape_failures_total"
+	ConfigReloadsMetric     = InferenceExtensionComponent + "_config_reloads_total"
+	DecisionsDroppedMetric  = InferenceExtensionComponent + "_scheduling_decisions_dropped_total"
+	CutOffStreamsMetric     = InferenceExtensionComponent + "_shutdown_cut_off_streams_total"
 )
 
 func TestRecordRequestCounterandSizes(t *testing.T) {
 	type requests struct {
+		poolNamespace   string
+		poolName        string
 		modelName       string
 		targetModelName string
 		reqSize         int
@@ -53,21 +62,29 @@ func TestRecordRequestCounterandSizes(t *testing.T) {
 		name: "multiple requests",
 		reqs: []requests{
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t10",
 				reqSize:         1200,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t10",
 				reqSize:         500,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t11",
 				reqSize:         2480,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p2",
 				modelName:       "m20",
 				targetModelName: "t20",
 				reqSize:         80,
@@ -78,8 +95,8 @@ func TestRecordRequestCounterandSizes(t *testing.T) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			for _, req := range scenario.reqs {
-				RecordRequestCounter(req.modelName, req.targetModelName)
-				RecordRequestSizes(req.modelName, req.targetModelName, req.reqSize)
+				RecordRequestCounter(req.poolNamespace, req.poolName, req.modelName, req.targetModelName)
+				RecordRequestSizes(req.poolNamespace, req.poolName, req.modelName, req.targetModelName, req.reqSize)
 			}
 			wantRequestTotal, err := os.Open("testdata/request_total_metric")
 			defer func() {
@@ -111,6 +128,8 @@ func TestRecordRequestCounterandSizes(t *testing.T) {
 
 func TestRecordRequestErrorCounter(t *testing.T) {
 	type requests struct {
+		poolNamespace   string
+		poolName        string
 		modelName       string
 		targetModelName string
 		error           string
@@ -124,21 +143,29 @@ func TestRecordRequestErrorCounter(t *testing.T) {
 			name: "multiple requests",
 			reqs: []requests{
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t10",
 					error:           errutil.Internal,
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t10",
 					error:           errutil.Internal,
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t11",
 					error:           errutil.ModelServerError,
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m20",
 					targetModelName: "t20",
 					error:           errutil.InferencePoolResourceExhausted,
@@ -150,7 +177,7 @@ func TestRecordRequestErrorCounter(t *testing.T) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			for _, req := range scenario.reqs {
-				RecordRequestErrCounter(req.modelName, req.targetModelName, req.error)
+				RecordRequestErrCounter(req.poolNamespace, req.poolName, req.modelName, req.targetModelName, req.error)
 			}
 
 			wantRequestErrorCounter, err := os.Open("testdata/request_error_total_metric")
@@ -173,6 +200,8 @@ func TestRecordRequestLatencies(t *testing.T) {
 	ctx := logutil.NewTestLoggerIntoContext(context.Background())
 	timeBaseline := time.Now()
 	type requests struct {
+		poolNamespace   string
+		poolName        string
 		modelName       string
 		targetModelName string
 		receivedTime    time.Time
@@ -187,24 +216,32 @@ func TestRecordRequestLatencies(t *testing.T) {
 			name: "multiple requests",
 			reqs: []requests{
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t10",
 					receivedTime:    timeBaseline,
 					completeTime:    timeBaseline.Add(time.Millisecond * 10),
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t10",
 					receivedTime:    timeBaseline,
 					completeTime:    timeBaseline.Add(time.Millisecond * 1600),
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t11",
 					receivedTime:    timeBaseline,
 					completeTime:    timeBaseline.Add(time.Millisecond * 60),
 				},
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m20",
 					targetModelName: "t20",
 					receivedTime:    timeBaseline,
@@ -216,6 +253,8 @@ func TestRecordRequestLatencies(t *testing.T) {
 			name: "invalid elapsed time",
 			reqs: []requests{
 				{
+					poolNamespace:   "default",
+					poolName:        "p1",
 					modelName:       "m10",
 					targetModelName: "t10",
 					receivedTime:    timeBaseline.Add(time.Millisecond * 10),
@@ -229,7 +268,7 @@ func TestRecordRequestLatencies(t *testing.T) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			for _, req := range scenario.reqs {
-				success := RecordRequestLatencies(ctx, req.modelName, req.targetModelName, req.receivedTime, req.completeTime)
+				success := RecordRequestLatencies(ctx, req.poolNamespace, req.poolName, req.modelName, req.targetModelName, req.receivedTime, req.completeTime)
 				if success == scenario.invalid {
 					t.Errorf("got record success(%v), but the request expects invalid(%v)", success, scenario.invalid)
 				}
@@ -253,6 +292,8 @@ func TestRecordRequestLatencies(t *testing.T) {
 
 func TestRecordResponseMetrics(t *testing.T) {
 	type responses struct {
+		poolNamespace   string
+		poolName        string
 		modelName       string
 		targetModelName string
 		inputToken      int
@@ -266,6 +307,8 @@ func TestRecordResponseMetrics(t *testing.T) {
 		name: "multiple requests",
 		resp: []responses{
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t10",
 				respSize:        1200,
@@ -273,6 +316,8 @@ func TestRecordResponseMetrics(t *testing.T) {
 				outputToken:     100,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t10",
 				respSize:        500,
@@ -280,6 +325,8 @@ func TestRecordResponseMetrics(t *testing.T) {
 				outputToken:     200,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m10",
 				targetModelName: "t11",
 				respSize:        2480,
@@ -287,6 +334,8 @@ func TestRecordResponseMetrics(t *testing.T) {
 				outputToken:     300,
 			},
 			{
+				poolNamespace:   "default",
+				poolName:        "p1",
 				modelName:       "m20",
 				targetModelName: "t20",
 				respSize:        80,
@@ -299,9 +348,9 @@ func TestRecordResponseMetrics(t *testing.T) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			for _, resp := range scenario.resp {
-				RecordInputTokens(resp.modelName, resp.targetModelName, resp.inputToken)
-				RecordOutputTokens(resp.modelName, resp.targetModelName, resp.outputToken)
-				RecordResponseSizes(resp.modelName, resp.targetModelName, resp.respSize)
+				RecordInputTokens(resp.poolNamespace, resp.poolName, resp.modelName, resp.targetModelName, resp.inputToken)
+				RecordOutputTokens(resp.poolNamespace, resp.poolName, resp.modelName, resp.targetModelName, resp.outputToken)
+				RecordResponseSizes(resp.poolNamespace, resp.poolName, resp.modelName, resp.targetModelName, resp.respSize)
 			}
 			wantResponseSize, err := os.Open("testdata/response_sizes_metric")
 			defer func() {
@@ -347,23 +396,38 @@ func TestRecordResponseMetrics(t *testing.T) {
 
 func TestInferencePoolMetrics(t *testing.T) {
 	scenarios := []struct {
-		name         string
-		poolName     string
-		kvCacheAvg   float64
-		queueSizeAvg float64
+		name           string
+		poolNamespace  string
+		poolName       string
+		kvCacheAvg     float64
+		queueSizeAvg   float64
+		stalePods      int
+		drainingPods   int
+		inFlight       int
+		scrapeFailures int
 	}{
 		{
-			name:         "basic test",
-			poolName:     "p1",
-			kvCacheAvg:   0.3,
-			queueSizeAvg: 0.4,
+			name:           "basic test",
+			poolNamespace:  "default",
+			poolName:       "p1",
+			kvCacheAvg:     0.3,
+			queueSizeAvg:   0.4,
+			stalePods:      2,
+			drainingPods:   1,
+			inFlight:       4,
+			scrapeFailures: 3,
 		},
 	}
 	Register()
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
-			RecordInferencePoolAvgKVCache(scenario.poolName, scenario.kvCacheAvg)
-			RecordInferencePoolAvgQueueSize(scenario.poolName, scenario.queueSizeAvg)
+			RecordInferencePoolAvgKVCache(scenario.poolNamespace, scenario.poolName, scenario.kvCacheAvg)
+			RecordInferencePoolAvgQueueSize(scenario.poolNamespace, scenario.poolName, scenario.queueSizeAvg)
+			RecordInferencePoolStalePods(scenario.poolNamespace, scenario.poolName, scenario.stalePods)
+			RecordInferencePoolDrainingPods(scenario.poolNamespace, scenario.poolName, scenario.drainingPods, scenario.inFlight)
+			for range scenario.scrapeFailures {
+				RecordInferencePoolScrapeFailure(scenario.poolNamespace, scenario.poolName)
+			}
 
 			wantKVCache, err := os.Open("testdata/kv_cache_avg_metrics")
 			defer func() {
@@ -390,6 +454,105 @@ func TestInferencePoolMetrics(t *testing.T) {
 			if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, wantQueueSize, QueueAvgSizeMetric); err != nil {
 				t.Error(err)
 			}
+
+			wantStalePods, err := os.Open("testdata/stale_pods_metrics")
+			defer func() {
+				if err := wantStalePods.Close(); err != nil {
+					t.Error(err)
+				}
+			}()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, wantStalePods, StalePodsMetric); err != nil {
+				t.Error(err)
+			}
+
+			wantDrainingPods, err := os.Open("testdata/draining_pods_metrics")
+			defer func() {
+				if err := wantDrainingPods.Close(); err != nil {
+					t.Error(err)
+				}
+			}()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, wantDrainingPods, DrainingPodsMetric, DrainingInFlightMetric); err != nil {
+				t.Error(err)
+			}
+
+			wantScrapeFailures, err := os.Open("testdata/scrape_failures_metrics")
+			defer func() {
+				if err := wantScrapeFailures.Close(); err != nil {
+					t.Error(err)
+				}
+			}()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, wantScrapeFailures, ScrapeFailuresMetric); err != nil {
+				t.Error(err)
+			}
 		})
 	}
 }
+
+func TestRecordConfigReload(t *testing.T) {
+	Register()
+	for _, result := range []string{"success", "success", "failure", "restart_required"} {
+		RecordConfigReload(result)
+	}
+
+	want, err := os.Open("testdata/config_reloads_metrics")
+	defer func() {
+		if err := want.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, want, ConfigReloadsMetric); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordSchedulingDecisionDropped(t *testing.T) {
+	Register()
+	for range 3 {
+		RecordSchedulingDecisionDropped()
+	}
+
+	want, err := os.Open("testdata/scheduling_decisions_dropped_metrics")
+	defer func() {
+		if err := want.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, want, DecisionsDroppedMetric); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordShutdownCutOffStreams(t *testing.T) {
+	Register()
+	RecordShutdownCutOffStreams(2)
+	RecordShutdownCutOffStreams(0)
+	RecordShutdownCutOffStreams(1)
+
+	want, err := os.Open("testdata/shutdown_cut_off_streams_metrics")
+	defer func() {
+		if err := want.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, want, CutOffStreamsMetric); err != nil {
+		t.Error(err)
+	}
+}
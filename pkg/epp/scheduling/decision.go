@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+// FilterStep is a filter applied to a request while walking the filter tree.
+type FilterStep struct {
+	Filter     string `json:"filter"`
+	InputPods  int    `json:"inputPods"`
+	OutputPods int    `json:"outputPods"`
+	// Succeeded is true if the filter left pods, the next filter is then applied to them. Otherwise
+	// the next filter on failure, if any, is applied to the input pods.
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Decision describes how the scheduler chose the target pod of a request.
+type Decision struct {
+	Time time.Time `json:"time"`
+	// Pool is the InferencePool of the scheduler, empty until the pool is synced.
+	Pool            string          `json:"pool,omitempty"`
+	Request         LLMRequest      `json:"request"`
+	StalenessPolicy StalenessPolicy `json:"stalenessPolicy"`
+	Picker          Picker          `json:"picker"`
+	// Generation identifies the version of the pod metrics snapshot the decision is based on.
+	Generation uint64 `json:"generation"`
+	// Pods are the pods of the snapshot the decision is based on.
+	Pods []string `json:"pods"`
+	// DrainingPods are the pods excluded because they are draining.
+	DrainingPods []string `json:"drainingPods,omitempty"`
+	// StalePods are the pods with stale metrics, treated according to the StalenessPolicy.
+	StalePods []string `json:"stalePods,omitempty"`
+	// Steps are the filters applied, in order.
+	Steps []FilterStep `json:"steps,omitempty"`
+	// Candidates are the pods left by the filters, the target pod is picked among them.
+	Candidates []string `json:"candidates,omitempty"`
+	// Target is the pod picked among the candidates. It is empty for dry runs.
+	Target string `json:"target,omitempty"`
+	// Error is the reason no candidate is left, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// DecisionRecorder records the decisions of the scheduler, e.g. DecisionLog. It must be safe for
+// concurrent use and should not block.
+type DecisionRecorder interface {
+	RecordDecision(d *Decision)
+}
+
+// DecisionRecorders records decisions to each of its recorders.
+type DecisionRecorders []DecisionRecorder
+
+func (r DecisionRecorders) RecordDecision(d *Decision) {
+	for _, recorder := range r {
+		recorder.RecordDecision(d)
+	}
+}
+
+// DryRun returns the decision of the scheduler for the given request without picking a target
+// pod, so that nothing is dispatched or tracked. The decision is not recorded.
+func (s *Scheduler) DryRun(ctx context.Context, req *LLMRequest) *Decision {
+	d := &Decision{Request: *req}
+	if _, _, err := s.candidates(log.FromContext(ctx).WithValues("request", req, "dryRun", true), req, d); err != nil {
+		d.Error = err.Error()
+	}
+	return d
+}
+
+// record records the snapshot the decision is based on.
+func (d *Decision) record(pool string, config Config, now time.Time, snapshot *datastore.PodSnapshot) {
+	d.Time = now
+	d.Pool = pool
+	d.StalenessPolicy = config.StalenessPolicy
+	d.Picker = config.Picker
+	d.Generation = snapshot.Generation
+	pods := snapshot.Pods
+	d.Pods = podNames(pods)
+	for _, pod := range pods {
+		if pod.Draining {
+			d.DrainingPods = append(d.DrainingPods, pod.NamespacedName.String())
+		} else if pod.IsStale(config.MetricsStalenessThreshold, now) {
+			d.StalePods = append(d.StalePods, pod.NamespacedName.String())
+		}
+	}
+}
+
+func podNames(pods []*datastore.PodMetrics) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.NamespacedName.String())
+	}
+	return names
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultDecisionLogSize is the default number of decisions kept by a DecisionLog.
+	DefaultDecisionLogSize = 100
+	// DefaultDecisionFileSinkBufferSize is the default number of decisions buffered by a
+	// DecisionFileSink before new decisions are dropped.
+	DefaultDecisionFileSinkBufferSize = 1000
+	// decisionFileSinkFlushInterval is the maximum time a written decision stays buffered.
+	decisionFileSinkFlushInterval = time.Second
+)
+
+// DecisionLog keeps the most recent decisions in a ring buffer for inspection.
+type DecisionLog struct {
+	mu        sync.Mutex
+	decisions []*Decision
+	// next is the index of the next decision to record.
+	next int
+	full bool
+}
+
+// NewDecisionLog returns a log keeping the given number of decisions, at least one.
+func NewDecisionLog(size int) *DecisionLog {
+	return &DecisionLog{decisions: make([]*Decision, max(size, 1))}
+}
+
+func (l *DecisionLog) RecordDecision(d *Decision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.decisions[l.next] = d
+	l.next = (l.next + 1) % len(l.decisions)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Decisions returns the decisions kept, oldest first. The decisions must not be modified.
+func (l *DecisionLog) Decisions() []*Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]*Decision(nil), l.decisions[:l.next]...)
+	}
+	return append(append(make([]*Decision, 0, len(l.decisions)), l.decisions[l.next:]...), l.decisions[:l.next]...)
+}
+
+// DecisionFileSink appends the decisions to a file as JSON lines for offline analysis. Decisions
+// are written asynchronously by Start, so that scheduling never waits for the file. Decisions
+// recorded while the buffer is full are dropped and counted in the
+// inference_extension_scheduling_decisions_dropped_total metric.
+type DecisionFileSink struct {
+	path      string
+	decisions chan *Decision
+}
+
+// NewDecisionFileSink returns a sink appending to the file at the given path, which is opened by
+// Start. A bufferSize <= 0 uses DefaultDecisionFileSinkBufferSize.
+func NewDecisionFileSink(path string, bufferSize int) *DecisionFileSink {
+	if bufferSize <= 0 {
+		bufferSize = DefaultDecisionFileSinkBufferSize
+	}
+	return &DecisionFileSink{path: path, decisions: make(chan *Decision, bufferSize)}
+}
+
+func (s *DecisionFileSink) RecordDecision(d *Decision) {
+	select {
+	case s.decisions <- d:
+	default:
+		metrics.RecordSchedulingDecisionDropped()
+	}
+}
+
+// Start opens the file for appending, creating it if needed, and writes the recorded decisions
+// to it until the context is done. It then writes the buffered decisions and closes the file.
+func (s *DecisionFileSink) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("path", s.path)
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open decision file: %w", err)
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	write := func(d *Decision) {
+		if err := enc.Encode(d); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to write scheduling decision")
+		}
+	}
+	flush := func() {
+		if err := w.Flush(); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to flush scheduling decisions")
+		}
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to close decision file")
+		}
+	}()
+
+	ticker := time.NewTicker(decisionFileSinkFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case d := <-s.decisions:
+			write(d)
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for {
+				select {
+				case d := <-s.decisions:
+					write(d)
+				default:
+					flush()
+					return nil
+				}
+			}
+		}
+	}
+}
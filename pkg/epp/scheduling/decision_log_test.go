@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecisionLog(t *testing.T) {
+	l := NewDecisionLog(3)
+	targets := func() []string {
+		var got []string
+		for _, d := range l.Decisions() {
+			got = append(got, d.Target)
+		}
+		return got
+	}
+	if got := targets(); len(got) != 0 {
+		t.Errorf("Expected no decisions, got %v", got)
+	}
+	for _, target := range []string{"a", "b"} {
+		l.RecordDecision(&Decision{Target: target})
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, targets()); diff != "" {
+		t.Errorf("Unexpected decisions (-want +got): %s", diff)
+	}
+	for _, target := range []string{"c", "d", "e"} {
+		l.RecordDecision(&Decision{Target: target})
+	}
+	if diff := cmp.Diff([]string{"c", "d", "e"}, targets()); diff != "" {
+		t.Errorf("Unexpected decisions after wrapping (-want +got): %s", diff)
+	}
+}
+
+func TestDecisionFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.jsonl")
+	sink := NewDecisionFileSink(path, 2)
+	// The buffer holds two decisions, the third is dropped as the sink is not started.
+	for _, target := range []string{"a", "b", "c"} {
+		sink.RecordDecision(&Decision{Target: target, Request: LLMRequest{Model: "model"}})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sink.Start(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d Decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, d.Target)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+		t.Errorf("Unexpected decisions written (-want +got): %s", diff)
+	}
+
+	// The file is opened when the sink is started, so that it is not leaked if the sink never is.
+	missing := NewDecisionFileSink(filepath.Join(t.TempDir(), "missing", "decisions.jsonl"), 0)
+	if err := missing.Start(ctx); err == nil {
+		t.Error("Expected an error for a file in a missing directory")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestDryRun(t *testing.T) {
+	now := time.Now()
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	pods := &sync.Map{}
+	for _, pm := range []*datastore.PodMetrics{
+		{
+			Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "draining"}, Draining: true},
+			Metrics: datastore.Metrics{UpdateTime: now},
+		},
+		{
+			Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "stale"}},
+		},
+		{
+			Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "idle"}},
+			Metrics: datastore.Metrics{UpdateTime: now, KVCacheUsagePercent: 0.1, MaxActiveModels: 4},
+		},
+		{
+			Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "busy"}},
+			Metrics: datastore.Metrics{UpdateTime: now, WaitingQueueSize: 100, KVCacheUsagePercent: 0.9, MaxActiveModels: 4},
+		},
+	} {
+		pods.Store(pm.NamespacedName, pm)
+	}
+	ds := datastore.NewFakeDatastore(pods, nil, pool)
+	scheduler := NewScheduler(ds)
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	tests := []struct {
+		name string
+		req  *LLMRequest
+		want *Decision
+	}{
+		{
+			name: "critical request",
+			req:  &LLMRequest{Model: "model", ResolvedTargetModel: "adapter", Critical: true},
+			want: &Decision{
+				Pool:            "/pool",
+				Request:         LLMRequest{Model: "model", ResolvedTargetModel: "adapter", Critical: true},
+				StalenessPolicy: StalenessPolicyPenalize,
+				Picker:          PickerRandom,
+				Pods:            []string{"/busy", "/draining", "/idle", "/stale"},
+				DrainingPods:    []string{"/draining"},
+				StalePods:       []string{"/stale"},
+				Steps: []FilterStep{
+					{Filter: "can serve model", InputPods: 2, OutputPods: 2, Succeeded: true},
+					{Filter: "has KV cache token capacity", InputPods: 2, OutputPods: 2, Succeeded: true},
+					{Filter: "critical request", InputPods: 2, OutputPods: 2, Succeeded: true},
+					{Filter: "low queueing filter", InputPods: 2, OutputPods: 1, Succeeded: true},
+					{Filter: "affinity LoRA", InputPods: 1, OutputPods: 0, Error: "no pods left"},
+					{Filter: "can accept LoRA Adapter", InputPods: 1, OutputPods: 1, Succeeded: true},
+					{Filter: "least queuing", InputPods: 1, OutputPods: 1, Succeeded: true},
+					{Filter: "least KV cache percent", InputPods: 1, OutputPods: 1, Succeeded: true},
+				},
+				Candidates: []string{"/idle"},
+			},
+		},
+		{
+			name: "dropped sheddable request",
+			req:  &LLMRequest{Model: "model", ResolvedTargetModel: "model"},
+			want: &Decision{
+				Pool:            "/pool",
+				Request:         LLMRequest{Model: "model", ResolvedTargetModel: "model"},
+				StalenessPolicy: StalenessPolicyPenalize,
+				Picker:          PickerRandom,
+				Pods:            []string{"/busy", "/draining", "/idle", "/stale"},
+				DrainingPods:    []string{"/draining"},
+				StalePods:       []string{"/stale"},
+				Steps: []FilterStep{
+					{Filter: "can serve model", InputPods: 2, OutputPods: 2, Succeeded: true},
+					{Filter: "has KV cache token capacity", InputPods: 2, OutputPods: 2, Succeeded: true},
+					{Filter: "critical request", InputPods: 2, OutputPods: 0, Error: "no pods left"},
+					{Filter: "has capacity for sheddable requests", InputPods: 2, OutputPods: 1, Succeeded: true},
+					{Filter: "least queuing", InputPods: 1, OutputPods: 1, Succeeded: true},
+					{Filter: "low cost LoRA", InputPods: 1, OutputPods: 1, Succeeded: true},
+					{Filter: "least KV cache percent", InputPods: 1, OutputPods: 1, Succeeded: true},
+				},
+				Candidates: []string{"/idle"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := scheduler.DryRun(ctx, test.req)
+			sort.Strings(got.Pods)
+			if diff := cmp.Diff(test.want, got, cmpopts.IgnoreFields(Decision{}, "Time")); diff != "" {
+				t.Errorf("Unexpected decision (-want +got): %s", diff)
+			}
+		})
+	}
+	if got := ds.PodInFlightRequests(types.NamespacedName{Name: "idle"}); got != 0 {
+		t.Errorf("Expected no request in flight after a dry run, got %d", got)
+	}
+}
+
+func TestScheduleWithDecision(t *testing.T) {
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+	pods := &sync.Map{}
+	pod := &datastore.PodMetrics{
+		Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod"}},
+		Metrics: datastore.Metrics{UpdateTime: time.Now()},
+	}
+	pods.Store(pod.NamespacedName, pod)
+	scheduler := NewScheduler(datastore.NewFakeDatastore(pods, nil, pool))
+	decisions := NewDecisionLog(10)
+	scheduler.SetDecisionRecorder(decisions)
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	got, d, err := scheduler.ScheduleWithDecision(ctx, &LLMRequest{Model: "model", ResolvedTargetModel: "model", Critical: true})
+	if err != nil {
+		t.Fatalf("Unexpected scheduling error: %v", err)
+	}
+	if got.NamespacedName != pod.NamespacedName || d.Target != "default/pod" || d.Pool != "default/pool" || len(d.Steps) == 0 {
+		t.Errorf("Unexpected target %s with decision %+v", got.NamespacedName, d)
+	}
+
+	// Failed decisions are recorded as well.
+	empty := NewScheduler(datastore.NewFakeDatastore(&sync.Map{}, nil, pool))
+	empty.SetDecisionRecorder(decisions)
+	if _, d, err = empty.ScheduleWithDecision(ctx, &LLMRequest{Model: "model"}); err == nil || d.Error == "" || d.Target != "" {
+		t.Errorf("Expected a failed decision, got %+v with error %v", d, err)
+	}
+	if got := len(decisions.Decisions()); got != 2 {
+		t.Errorf("Expected two recorded decisions, got %d", got)
+	}
+
+	// Dry runs are not recorded.
+	scheduler.DryRun(ctx, &LLMRequest{Model: "model"})
+	if got := len(decisions.Decisions()); got != 2 {
+		t.Errorf("Expected two recorded decisions after a dry run, got %d", got)
+	}
+
+	// Without recorder, the decision is still built and returned.
+	unrecorded := NewScheduler(datastore.NewFakeDatastore(pods, nil, pool))
+	got, d, err = unrecorded.ScheduleWithDecision(ctx, &LLMRequest{Model: "model", ResolvedTargetModel: "model", Critical: true})
+	if err != nil || got.NamespacedName != pod.NamespacedName {
+		t.Errorf("Unexpected target %s with error %v", got.NamespacedName, err)
+	}
+	if d == nil || d.Target != "default/pod" {
+		t.Errorf("Expected a decision without recorder, got %+v", d)
+	}
+}
@@ -58,10 +58,23 @@ func (f *filter) Name() string {
 }
 
 func (f *filter) Filter(logger logr.Logger, req *LLMRequest, pods []*datastore.PodMetrics) ([]*datastore.PodMetrics, error) {
+	return f.filterWithSteps(logger, req, pods, nil)
+}
+
+// filterWithSteps applies the filter like Filter, and appends the filters applied to the given
+// steps, if not nil.
+func (f *filter) filterWithSteps(logger logr.Logger, req *LLMRequest, pods []*datastore.PodMetrics, steps *[]FilterStep) ([]*datastore.PodMetrics, error) {
 	loggerTrace := logger.V(logutil.TRACE)
 	loggerTrace.Info("Running a filter", "name", f.Name(), "podCount", len(pods))
 
 	filtered, err := f.filter(logger, req, pods)
+	if steps != nil {
+		step := FilterStep{Filter: f.Name(), InputPods: len(pods), OutputPods: len(filtered), Succeeded: err == nil && len(filtered) > 0}
+		if err != nil {
+			step.Error = err.Error()
+		}
+		*steps = append(*steps, step)
+	}
 
 	next := f.nextOnSuccessOrFailure
 	if err == nil && len(filtered) > 0 {
@@ -74,7 +87,7 @@ func (f *filter) Filter(logger logr.Logger, req *LLMRequest, pods []*datastore.P
 		}
 		loggerTrace.Info("Filter succeeded", "filter", f.Name(), "next", next.Name(), "filteredPodCount", len(filtered))
 		// On success, pass the filtered result to the next filter.
-		return next.Filter(logger, req, filtered)
+		return next.filterWithSteps(logger, req, filtered, steps)
 	} else {
 		if f.nextOnFailure == nil && f.nextOnSuccessOrFailure == nil {
 			// No succeeding filters to run, return.
@@ -85,7 +98,7 @@ func (f *filter) Filter(logger logr.Logger, req *LLMRequest, pods []*datastore.P
 		}
 		loggerTrace.Info("Filter failed", "filter", f.Name(), "next", next.Name())
 		// On failure, pass the initial set of pods to the next filter.
-		return next.Filter(logger, req, pods)
+		return next.filterWithSteps(logger, req, pods, steps)
 	}
 }
 
@@ -138,8 +151,10 @@ func leastQueuingFilterFunc(logger logr.Logger, req *LLMRequest, pods []*datasto
 	return filtered, nil
 }
 
-func lowQueueingPodPredicate(_ *LLMRequest, pod *datastore.PodMetrics) bool {
-	return pod.WaitingQueueSize < queueingThresholdLoRA
+func lowQueueingPodPredicate(queueingThresholdLoRA int) podPredicate {
+	return func(_ *LLMRequest, pod *datastore.PodMetrics) bool {
+		return pod.WaitingQueueSize < queueingThresholdLoRA
+	}
 }
 
 // leastKVCacheFilterFunc finds the max and min KV cache of all pods, divides the whole range
@@ -194,6 +209,28 @@ func canAcceptNewLoraPredicate(req *LLMRequest, pod *datastore.PodMetrics) bool
 	return len(pod.ActiveModels) < pod.MaxActiveModels
 }
 
+// canServeModelPredicate is a filter function to check whether a pod serves the target model as a
+// base model or an adapter. Pods whose served models were not discovered are assumed to serve it.
+func canServeModelPredicate(req *LLMRequest, pod *datastore.PodMetrics) bool {
+	if !pod.ServedModels.Known() || pod.ServedModels.Serves(req.ResolvedTargetModel) {
+		return true
+	}
+	// Adapters may have been loaded since the last discovery.
+	_, ok := pod.ActiveModels[req.ResolvedTargetModel]
+	return ok
+}
+
+// hasKVCacheTokenCapacityPredicate is a filter function to check whether a pod has enough free KV
+// cache tokens to hold the estimated tokens of the request. Pods with an unknown KV cache capacity
+// and requests with an unknown token estimate always pass.
+func hasKVCacheTokenCapacityPredicate(req *LLMRequest, pod *datastore.PodMetrics) bool {
+	if req.EstimatedTokens <= 0 || pod.KvCacheMaxTokenCapacity <= 0 {
+		return true
+	}
+	used := pod.KVCacheUsagePercent * float64(pod.KvCacheMaxTokenCapacity)
+	return float64(pod.KvCacheMaxTokenCapacity)-used >= float64(req.EstimatedTokens)
+}
+
 func criticalRequestPredicate(req *LLMRequest, pod *datastore.PodMetrics) bool {
 	return req.Critical
 }
@@ -19,10 +19,12 @@ package scheduling
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -45,6 +47,103 @@ func TestFilter(t *testing.T) {
 			}},
 			err: true,
 		},
+		{
+			name:   "default filter, request does not fit into the KV cache of a pod",
+			filter: defaultFilter,
+			req: &LLMRequest{
+				Model:               "critical",
+				ResolvedTargetModel: "critical",
+				Critical:            true,
+				EstimatedTokens:     2000,
+			},
+			// pod1 has the lowest KV cache utilization, but not enough free tokens for the request.
+			input: []*datastore.PodMetrics{
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+					Metrics: datastore.Metrics{
+						KVCacheUsagePercent:     0.2,
+						KvCacheMaxTokenCapacity: 2000,
+					},
+				},
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+					Metrics: datastore.Metrics{
+						KVCacheUsagePercent:     0.5,
+						KvCacheMaxTokenCapacity: 8000,
+					},
+				},
+			},
+			output: []*datastore.PodMetrics{
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+					Metrics: datastore.Metrics{
+						KVCacheUsagePercent:     0.5,
+						KvCacheMaxTokenCapacity: 8000,
+					},
+				},
+			},
+		},
+		{
+			name:   "default filter, model not served by any pod",
+			filter: defaultFilter,
+			req: &LLMRequest{
+				Model:               "lora",
+				ResolvedTargetModel: "lora",
+				Critical:            true,
+			},
+			// Both pods are known not to serve the adapter.
+			input: []*datastore.PodMetrics{
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+					Metrics: datastore.Metrics{MaxActiveModels: 2},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+					Metrics: datastore.Metrics{MaxActiveModels: 2},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+			},
+			output: []*datastore.PodMetrics{},
+			err:    true,
+		},
+		{
+			name:   "default filter, served models not discovered yet",
+			filter: defaultFilter,
+			req: &LLMRequest{
+				Model:               "lora",
+				ResolvedTargetModel: "lora",
+				Critical:            true,
+			},
+			// pod1 is known not to serve the adapter, pod2 may serve it as its served models are
+			// unknown.
+			input: []*datastore.PodMetrics{
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod1"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.2, MaxActiveModels: 2},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.5, MaxActiveModels: 2},
+				},
+			},
+			output: []*datastore.PodMetrics{
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "pod2"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.5, MaxActiveModels: 2},
+				},
+			},
+		},
 		{
 			name:   "default filter, critical request",
 			filter: defaultFilter,
@@ -414,6 +513,115 @@ func TestFilterFunc(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "can serve model",
+			f:    toFilterFunc(canServeModelPredicate),
+			req:  &LLMRequest{Model: "model", ResolvedTargetModel: "lora"},
+			input: []*datastore.PodMetrics{
+				// Served models are unknown, should be returned.
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "unknown"}},
+				},
+				// Lists the adapter, should be returned.
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "serves-adapter"}},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						Adapters:   map[string]string{"lora": "base"},
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+				// Does not list the adapter but has it loaded, should be returned.
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "active-adapter"}},
+					Metrics: datastore.Metrics{
+						ActiveModels: map[string]int{"lora": 0},
+					},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+				// Only serves another model, should not be returned.
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "other-model"}},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("other-base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+			},
+			output: []*datastore.PodMetrics{
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "unknown"}},
+				},
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "serves-adapter"}},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						Adapters:   map[string]string{"lora": "base"},
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+				{
+					Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "active-adapter"}},
+					Metrics: datastore.Metrics{
+						ActiveModels: map[string]int{"lora": 0},
+					},
+					ServedModels: datastore.ServedModels{
+						BaseModels: sets.New("base"),
+						UpdateTime: time.Unix(1, 0),
+					},
+				},
+			},
+		},
+		{
+			name: "has KV cache token capacity",
+			f:    toFilterFunc(hasKVCacheTokenCapacityPredicate),
+			req:  &LLMRequest{EstimatedTokens: 300},
+			input: []*datastore.PodMetrics{
+				// 500 free tokens, should be returned.
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "free"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.5, KvCacheMaxTokenCapacity: 1000},
+				},
+				// 100 free tokens, should not be returned.
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "full"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.9, KvCacheMaxTokenCapacity: 1000},
+				},
+				// Unknown capacity, should be returned.
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "unknown"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.9},
+				},
+			},
+			output: []*datastore.PodMetrics{
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "free"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.5, KvCacheMaxTokenCapacity: 1000},
+				},
+				{
+					Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "unknown"}},
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 0.9},
+				},
+			},
+		},
+		{
+			name: "has KV cache token capacity, unknown request tokens",
+			f:    toFilterFunc(hasKVCacheTokenCapacityPredicate),
+			req:  &LLMRequest{},
+			input: []*datastore.PodMetrics{
+				{
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 1, KvCacheMaxTokenCapacity: 1000},
+				},
+			},
+			output: []*datastore.PodMetrics{
+				{
+					Metrics: datastore.Metrics{KVCacheUsagePercent: 1, KvCacheMaxTokenCapacity: 1000},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
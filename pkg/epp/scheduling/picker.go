@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+	"math/rand"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+// Picker defines how the scheduler picks the target pod among the candidates left by the filters.
+type Picker string
+
+const (
+	// PickerRandom picks a random candidate.
+	PickerRandom Picker = "Random"
+	// PickerLeastInFlightRequests picks the candidate with the fewest requests in flight from this
+	// EPP, ties are broken randomly. It reacts faster than the scraped metrics to bursts of requests.
+	PickerLeastInFlightRequests Picker = "LeastInFlightRequests"
+
+	DefaultPicker = PickerRandom
+)
+
+// ParsePicker validates the given picker name.
+func ParsePicker(picker string) (Picker, error) {
+	switch p := Picker(picker); p {
+	case PickerRandom, PickerLeastInFlightRequests:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown picker %q, must be one of %q or %q", picker, PickerRandom, PickerLeastInFlightRequests)
+	}
+}
+
+// pick returns the target pod among the given candidates, which must not be empty.
+func (s *Scheduler) pick(picker Picker, pods []*datastore.PodMetrics) *datastore.PodMetrics {
+	if picker != PickerLeastInFlightRequests {
+		return pods[rand.Intn(len(pods))]
+	}
+	var least []*datastore.PodMetrics
+	min := -1
+	for _, pod := range pods {
+		inFlight := s.datastore.PodInFlightRequests(pod.NamespacedName)
+		switch {
+		case min < 0 || inFlight < min:
+			min = inFlight
+			least = append(least[:0], pod)
+		case inFlight == min:
+			least = append(least, pod)
+		}
+	}
+	return least[rand.Intn(len(least))]
+}
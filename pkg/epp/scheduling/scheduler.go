@@ -19,37 +19,41 @@ package scheduling
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
 const (
-	// TODO(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/16) Make this configurable.
-	kvCacheThreshold = 0.8
-	// TODO(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/16) Make this configurable.
-	queueThresholdCritical = 5
-	// TODO(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/16) Make this configurable.
-	// the threshold for queued requests to be considered low below which we can prioritize LoRA affinity.
-	// The value of 50 is arrived heuristicically based on experiments.
-	queueingThresholdLoRA = 50
+	// DefaultKVCacheThreshold is the KV cache usage below which a pod without queue has capacity
+	// for sheddable requests.
+	DefaultKVCacheThreshold = 0.8
+	// DefaultQueueThresholdCritical is the queue size up to which a pod has capacity for sheddable
+	// requests.
+	DefaultQueueThresholdCritical = 5
+	// DefaultQueueingThresholdLoRA is the threshold for queued requests to be considered low below
+	// which we can prioritize LoRA affinity. The value of 50 is arrived heuristicically based on
+	// experiments.
+	DefaultQueueingThresholdLoRA = 50
 )
 
-var (
-	defaultFilter = &filter{
-		name:          "critical request",
-		filter:        toFilterFunc(criticalRequestPredicate),
-		nextOnSuccess: lowLatencyFilter,
-		nextOnFailure: sheddableRequestFilter,
-	}
+// defaultFilter is the filter of the default config.
+var defaultFilter = newFilter(DefaultConfig())
 
+// newFilter returns the filter chain deciding the candidate pods of a request, with the
+// thresholds of the given config.
+func newFilter(config Config) *filter {
 	// queueLoRAAndKVCacheFilter applied least queue -> low cost lora ->  least KV Cache filter
-	queueLoRAAndKVCacheFilter = &filter{
+	queueLoRAAndKVCacheFilter := &filter{
 		name:   "least queuing",
 		filter: leastQueuingFilterFunc,
 		nextOnSuccessOrFailure: &filter{
@@ -63,7 +67,7 @@ var (
 	}
 
 	// queueAndKVCacheFilter applies least queue followed by least KV Cache filter
-	queueAndKVCacheFilter = &filter{
+	queueAndKVCacheFilter := &filter{
 		name:   "least queuing",
 		filter: leastQueuingFilterFunc,
 		nextOnSuccessOrFailure: &filter{
@@ -72,9 +76,9 @@ var (
 		},
 	}
 
-	lowLatencyFilter = &filter{
+	lowLatencyFilter := &filter{
 		name:   "low queueing filter",
-		filter: toFilterFunc((lowQueueingPodPredicate)),
+		filter: toFilterFunc(lowQueueingPodPredicate(config.QueueingThresholdLoRA)),
 		nextOnSuccess: &filter{
 			name:          "affinity LoRA",
 			filter:        toFilterFunc(loRAAffinityPredicate),
@@ -88,12 +92,12 @@ var (
 		nextOnFailure: queueLoRAAndKVCacheFilter,
 	}
 
-	sheddableRequestFilter = &filter{
+	sheddableRequestFilter := &filter{
 		// When there is at least one model server that's not queuing requests, and still has KV
 		// cache below a certain threshold, we consider this model server has capacity to handle
 		// a sheddable request without impacting critical requests.
 		name:          "has capacity for sheddable requests",
-		filter:        toFilterFunc(noQueueAndLessThanKVCacheThresholdPredicate(queueThresholdCritical, kvCacheThreshold)),
+		filter:        toFilterFunc(noQueueAndLessThanKVCacheThresholdPredicate(config.QueueThresholdCritical, config.KVCacheThreshold)),
 		nextOnSuccess: queueLoRAAndKVCacheFilter,
 		// If all pods are queuing or running above the KVCache threshold, we drop the sheddable
 		// request to make room for critical requests.
@@ -107,31 +111,256 @@ var (
 			},
 		},
 	}
+
+	criticalRequestFilter := &filter{
+		name:          "critical request",
+		filter:        toFilterFunc(criticalRequestPredicate),
+		nextOnSuccess: lowLatencyFilter,
+		nextOnFailure: sheddableRequestFilter,
+	}
+
+	// kvCacheTokenCapacityFilter prefers pods that have enough free KV cache tokens to hold the
+	// request, so that it does not cause preemption of running requests. If no pod has enough room,
+	// all pods are considered.
+	kvCacheTokenCapacityFilter := &filter{
+		name:                   "has KV cache token capacity",
+		filter:                 toFilterFunc(hasKVCacheTokenCapacityPredicate),
+		nextOnSuccessOrFailure: criticalRequestFilter,
+	}
+
+	return &filter{
+		// Pods that are known not to serve the target model are never considered, regardless of
+		// their load. Pods whose served models are unknown, because they were not discovered yet or
+		// the model server does not support the models endpoint, are assumed to serve it.
+		name:          "can serve model",
+		filter:        toFilterFunc(canServeModelPredicate),
+		nextOnSuccess: kvCacheTokenCapacityFilter,
+		nextOnFailure: &filter{
+			name: "model not served",
+			filter: func(logger logr.Logger, req *LLMRequest, pods []*datastore.PodMetrics) ([]*datastore.PodMetrics, error) {
+				return []*datastore.PodMetrics{}, errutil.Error{
+					Code: errutil.BadConfiguration, Msg: fmt.Sprintf("no pod serves the target model %q", req.ResolvedTargetModel),
+				}
+			},
+		},
+	}
+}
+
+const (
+	// DefaultMetricsStalenessThreshold is larger than the metrics fetch timeout, so that a single
+	// hanging scrape does not render the metrics of a pod stale.
+	DefaultMetricsStalenessThreshold = 10 * time.Second
+	DefaultStalenessPolicy           = StalenessPolicyPenalize
 )
 
-func NewScheduler(datastore datastore.Datastore) *Scheduler {
-	return &Scheduler{
-		datastore: datastore,
-		filter:    defaultFilter,
+const (
+	// MetricsStalenessThresholdAnnotation can be set on an InferencePool to override the metrics
+	// staleness threshold of the scheduler for the pool, e.g. "5s".
+	MetricsStalenessThresholdAnnotation = "inference.networking.x-k8s.io/metrics-staleness-threshold"
+	// StalenessPolicyAnnotation can be set on an InferencePool to override the staleness policy of
+	// the scheduler for the pool.
+	StalenessPolicyAnnotation = "inference.networking.x-k8s.io/staleness-policy"
+)
+
+// Config holds the tunable parameters of the scheduler.
+type Config struct {
+	// MetricsStalenessThreshold is the maximum age of pod metrics before they are considered stale.
+	// A non-positive value disables staleness handling.
+	MetricsStalenessThreshold time.Duration
+	// StalenessPolicy defines how pods with stale metrics are treated.
+	StalenessPolicy StalenessPolicy
+	// KVCacheThreshold is the KV cache usage, between 0 and 1, below which a pod without queue has
+	// capacity for sheddable requests.
+	KVCacheThreshold float64
+	// QueueThresholdCritical is the queue size up to which a pod has capacity for sheddable
+	// requests.
+	QueueThresholdCritical int
+	// QueueingThresholdLoRA is the queue size below which LoRA affinity is prioritized.
+	QueueingThresholdLoRA int
+	// Picker picks the target pod among the candidates left by the filters.
+	Picker Picker
+}
+
+// DefaultConfig returns the default scheduler configuration.
+func DefaultConfig() Config {
+	return Config{
+		MetricsStalenessThreshold: DefaultMetricsStalenessThreshold,
+		StalenessPolicy:           DefaultStalenessPolicy,
+		KVCacheThreshold:          DefaultKVCacheThreshold,
+		QueueThresholdCritical:    DefaultQueueThresholdCritical,
+		QueueingThresholdLoRA:     DefaultQueueingThresholdLoRA,
+		Picker:                    DefaultPicker,
+	}
+}
+
+// WithPoolOverrides returns a copy of the config with the overrides set in the annotations of an
+// InferencePool applied.
+func (c Config) WithPoolOverrides(annotations map[string]string) (Config, error) {
+	if v, ok := annotations[MetricsStalenessThresholdAnnotation]; ok {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			return c, fmt.Errorf("invalid %s annotation: %w", MetricsStalenessThresholdAnnotation, err)
+		}
+		c.MetricsStalenessThreshold = threshold
+	}
+	if v, ok := annotations[StalenessPolicyAnnotation]; ok {
+		policy, err := ParseStalenessPolicy(v)
+		if err != nil {
+			return c, fmt.Errorf("invalid %s annotation: %w", StalenessPolicyAnnotation, err)
+		}
+		c.StalenessPolicy = policy
 	}
+	return c, nil
+}
+
+func NewScheduler(datastore datastore.Datastore) *Scheduler {
+	return NewSchedulerWithConfig(datastore, DefaultConfig())
+}
+
+func NewSchedulerWithConfig(datastore datastore.Datastore, config Config) *Scheduler {
+	s := &Scheduler{datastore: datastore}
+	s.SetConfig(config)
+	return s
 }
 
 type Scheduler struct {
 	datastore datastore.Datastore
-	filter    Filter
+	// config is the config set by SetConfig, with its filter.
+	config atomic.Pointer[schedulerConfig]
+	// poolConfig caches the config with the overrides of the current InferencePool applied.
+	poolConfig atomic.Pointer[schedulerConfig]
+	// recorder records the scheduling decisions, if not nil.
+	recorder DecisionRecorder
+}
+
+// schedulerConfig is a config with its filter. Configs with the overrides of an InferencePool
+// applied record the pool and the config they are based on.
+type schedulerConfig struct {
+	pool   *v1alpha1.InferencePool
+	base   *schedulerConfig
+	config Config
+	filter *filter
+}
+
+// SetConfig replaces the config of the scheduler. It is safe to call while requests are
+// scheduled, which use either the old or the new config.
+func (s *Scheduler) SetConfig(config Config) {
+	s.config.Store(&schedulerConfig{config: config, filter: newFilter(config)})
+}
+
+// configFor returns the scheduler config for the InferencePool of the datastore, with its filter.
+// The config is recomputed whenever the pool is updated in the datastore, or the config is set.
+func (s *Scheduler) configFor(logger logr.Logger) (Config, *filter) {
+	base := s.config.Load()
+	pool, err := s.datastore.PoolGet()
+	if err != nil {
+		return base.config, base.filter
+	}
+	if cached := s.poolConfig.Load(); cached != nil && cached.pool == pool && cached.base == base {
+		return cached.config, cached.filter
+	}
+	config, err := base.config.WithPoolOverrides(pool.Annotations)
+	if err != nil {
+		logger.V(logutil.DEFAULT).Error(err, "Ignoring the scheduler config overrides of the InferencePool", "pool", pool.Name)
+		config = base.config
+	}
+	// The overrides do not change the thresholds of the filter.
+	s.poolConfig.Store(&schedulerConfig{pool: pool, base: base, config: config, filter: base.filter})
+	return config, base.filter
+}
+
+// poolName returns the namespaced name of the InferencePool of the datastore, if synced.
+func (s *Scheduler) poolName() string {
+	pool, err := s.datastore.PoolGet()
+	if err != nil {
+		return ""
+	}
+	return types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}.String()
+}
+
+// Config returns the config of the scheduler, with the overrides of the InferencePool applied.
+func (s *Scheduler) Config(ctx context.Context) Config {
+	config, _ := s.configFor(log.FromContext(ctx))
+	return config
+}
+
+// SetDecisionRecorder sets the recorder of the scheduling decisions. It must be called before
+// requests are scheduled.
+func (s *Scheduler) SetDecisionRecorder(recorder DecisionRecorder) {
+	s.recorder = recorder
 }
 
 // Schedule finds the target pod based on metrics and the requested lora adapter.
 func (s *Scheduler) Schedule(ctx context.Context, req *LLMRequest) (targetPod datastore.PodMetrics, err error) {
+	targetPod, _, err = s.ScheduleWithDecision(ctx, req)
+	return targetPod, err
+}
+
+// ScheduleWithDecision finds the target pod like Schedule, and returns the decision trace
+// explaining the choice, also if no pod was found. The decision is always built and logged as a
+// single line, and recorded by the DecisionRecorder, if any.
+func (s *Scheduler) ScheduleWithDecision(ctx context.Context, req *LLMRequest) (datastore.PodMetrics, *Decision, error) {
 	logger := log.FromContext(ctx).WithValues("request", req)
-	podMetrics := s.datastore.PodGetAll()
-	logger.V(logutil.VERBOSE).Info("Scheduling a request", "metrics", podMetrics)
-	pods, err := s.filter.Filter(logger, req, podMetrics)
+	d := &Decision{Request: *req}
+	defer func() {
+		logger.V(logutil.DEFAULT).Info("Scheduling decision", "decision", d)
+		if s.recorder != nil {
+			s.recorder.RecordDecision(d)
+		}
+	}()
+	config, pods, err := s.candidates(logger, req, d)
+	if err != nil {
+		d.Error = err.Error()
+		return datastore.PodMetrics{}, d, err
+	}
+	logger.V(logutil.VERBOSE).Info("Picking a pod from the candidates", "picker", config.Picker, "candidatePods", pods)
+	target := s.pick(config.Picker, pods)
+	d.Target = target.NamespacedName.String()
+	return *target, d, nil
+}
+
+// candidates returns the pods the target pod of the request is picked from, and the config they
+// were chosen with. The decision is recorded in d, if not nil.
+func (s *Scheduler) candidates(logger logr.Logger, req *LLMRequest, d *Decision) (Config, []*datastore.PodMetrics, error) {
+	// All decisions for the request are based on a single consistent snapshot of the pods.
+	snapshot := s.datastore.PodSnapshot()
+	podMetrics := snapshot.Pods
+	logger.V(logutil.VERBOSE).Info("Scheduling a request", "generation", snapshot.Generation, "metrics", podMetrics)
+	config, filter := s.configFor(logger)
+	now := time.Now()
+	if d != nil {
+		d.record(s.poolName(), config, now, snapshot)
+	}
+	podMetrics = excludeDrainingPods(podMetrics)
+	if len(podMetrics) == 0 {
+		return config, nil, errors.New("no candidate pods available, all pods may be draining")
+	}
+	podMetrics = applyStalenessPolicy(logger, config.StalenessPolicy, config.MetricsStalenessThreshold, now, podMetrics)
+	if len(podMetrics) == 0 {
+		return config, nil, errors.New("no candidate pods available, all pods may have stale metrics")
+	}
+	var steps *[]FilterStep
+	if d != nil {
+		steps = &d.Steps
+	}
+	pods, err := filter.filterWithSteps(logger, req, podMetrics, steps)
 	if err != nil || len(pods) == 0 {
-		return datastore.PodMetrics{}, fmt.Errorf(
+		return config, nil, fmt.Errorf(
 			"failed to apply filter, resulted %v pods, this should never happen: %w", len(pods), err)
 	}
-	logger.V(logutil.VERBOSE).Info("Selecting a random pod from the candidates", "candidatePods", pods)
-	i := rand.Intn(len(pods))
-	return *pods[i], nil
+	if d != nil {
+		d.Candidates = podNames(pods)
+	}
+	return config, pods, nil
+}
+
+// excludeDrainingPods returns the pods that are not draining.
+func excludeDrainingPods(pods []*datastore.PodMetrics) []*datastore.PodMetrics {
+	res := make([]*datastore.PodMetrics, 0, len(pods))
+	for _, pod := range pods {
+		if !pod.Draining {
+			res = append(res, pod)
+		}
+	}
+	return res
 }
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestConfigWithPoolOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        Config
+		wantErr     bool
+	}{
+		{
+			name: "no overrides",
+			want: DefaultConfig(),
+		},
+		{
+			name: "overrides",
+			annotations: map[string]string{
+				MetricsStalenessThresholdAnnotation: "3s",
+				StalenessPolicyAnnotation:           string(StalenessPolicyExclude),
+			},
+			want: func() Config {
+				c := DefaultConfig()
+				c.MetricsStalenessThreshold = 3 * time.Second
+				c.StalenessPolicy = StalenessPolicyExclude
+				return c
+			}(),
+		},
+		{
+			name:        "invalid threshold",
+			annotations: map[string]string{MetricsStalenessThresholdAnnotation: "soon"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid policy",
+			annotations: map[string]string{StalenessPolicyAnnotation: "Ignore"},
+			wantErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DefaultConfig().WithPoolOverrides(test.annotations)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error, got %v, want error %v", err, test.wantErr)
+			}
+			if err == nil {
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Errorf("Unexpected config (-want +got): %v", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulePoolOverrides(t *testing.T) {
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	pods := &sync.Map{}
+	stale := &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "stale"}}}
+	pods.Store(stale.NamespacedName, stale)
+	ds := datastore.NewFakeDatastore(pods, nil, pool)
+	scheduler := NewScheduler(ds)
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	req := &LLMRequest{Model: "model", ResolvedTargetModel: "model", Critical: true}
+
+	// By default, stale pods are used if there is no fresh pod.
+	if _, err := scheduler.Schedule(ctx, req); err != nil {
+		t.Fatalf("Unexpected scheduling error: %v", err)
+	}
+
+	// The pool overrides the staleness policy to exclude stale pods.
+	updated := pool.DeepCopy()
+	updated.Annotations = map[string]string{StalenessPolicyAnnotation: string(StalenessPolicyExclude)}
+	ds.PoolSet(updated)
+	if _, err := scheduler.Schedule(ctx, req); err == nil {
+		t.Errorf("Expected a scheduling error with only stale pods and the %s policy", StalenessPolicyExclude)
+	}
+
+	// Removing the override restores the default policy.
+	ds.PoolSet(pool.DeepCopy())
+	if _, err := scheduler.Schedule(ctx, req); err != nil {
+		t.Errorf("Unexpected scheduling error: %v", err)
+	}
+}
+
+func TestSchedulerSetConfig(t *testing.T) {
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	pods := &sync.Map{}
+	stale := &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "stale"}}}
+	pods.Store(stale.NamespacedName, stale)
+	ds := datastore.NewFakeDatastore(pods, nil, pool)
+	scheduler := NewScheduler(ds)
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	req := &LLMRequest{Model: "model", ResolvedTargetModel: "model", Critical: true}
+
+	if _, err := scheduler.Schedule(ctx, req); err != nil {
+		t.Fatalf("Unexpected scheduling error: %v", err)
+	}
+	// The new config applies to the following requests, although the pool did not change.
+	config := DefaultConfig()
+	config.StalenessPolicy = StalenessPolicyExclude
+	scheduler.SetConfig(config)
+	if _, err := scheduler.Schedule(ctx, req); err == nil {
+		t.Errorf("Expected a scheduling error with only stale pods and the %s policy", StalenessPolicyExclude)
+	}
+	scheduler.SetConfig(DefaultConfig())
+	if _, err := scheduler.Schedule(ctx, req); err != nil {
+		t.Errorf("Unexpected scheduling error: %v", err)
+	}
+}
+
+func TestSchedulerSetConfigThresholds(t *testing.T) {
+	now := time.Now()
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	pods := &sync.Map{}
+	busy := &datastore.PodMetrics{
+		Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "busy"}},
+		Metrics: datastore.Metrics{UpdateTime: now, WaitingQueueSize: 3, KVCacheUsagePercent: 0.5},
+	}
+	pods.Store(busy.NamespacedName, busy)
+	scheduler := NewScheduler(datastore.NewFakeDatastore(pods, nil, pool))
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	sheddable := &LLMRequest{Model: "model", ResolvedTargetModel: "model"}
+
+	if _, err := scheduler.Schedule(ctx, sheddable); err != nil {
+		t.Fatalf("Unexpected scheduling error below the default thresholds: %v", err)
+	}
+	config := DefaultConfig()
+	config.QueueThresholdCritical = 2
+	scheduler.SetConfig(config)
+	if _, err := scheduler.Schedule(ctx, sheddable); err == nil {
+		t.Errorf("Expected the sheddable request to be dropped above the queue threshold")
+	}
+}
+
+func TestPickLeastInFlightRequests(t *testing.T) {
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	ds := datastore.NewDatastore()
+	ds.PoolSet(pool)
+	scheduler := NewScheduler(ds)
+	var candidates []*datastore.PodMetrics
+	for _, name := range []string{"a", "b", "c"} {
+		candidates = append(candidates, &datastore.PodMetrics{Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: name}}})
+	}
+	ds.PodTrackRequest(types.NamespacedName{Name: "a"})
+	ds.PodTrackRequest(types.NamespacedName{Name: "c"})
+	ds.PodTrackRequest(types.NamespacedName{Name: "c"})
+	for range 10 {
+		if got := scheduler.pick(PickerLeastInFlightRequests, candidates); got.NamespacedName.Name != "b" {
+			t.Fatalf("Expected the pod without requests in flight to be picked, got %s", got.NamespacedName)
+		}
+	}
+}
+
+func TestParsePicker(t *testing.T) {
+	if got, err := ParsePicker("LeastInFlightRequests"); err != nil || got != PickerLeastInFlightRequests {
+		t.Errorf("Unexpected ParsePicker result %q, %v", got, err)
+	}
+	if _, err := ParsePicker("RoundRobin"); err == nil {
+		t.Errorf("Expected an error for an unknown picker")
+	}
+}
+
+func TestScheduleExcludesDrainingPods(t *testing.T) {
+	now := time.Now()
+	pool := &v1alpha1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+	pods := &sync.Map{}
+	draining := &datastore.PodMetrics{
+		Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "draining"}, Draining: true},
+		Metrics: datastore.Metrics{UpdateTime: now},
+	}
+	pods.Store(draining.NamespacedName, draining)
+	ds := datastore.NewFakeDatastore(pods, nil, pool)
+	scheduler := NewScheduler(ds)
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	req := &LLMRequest{Model: "model", ResolvedTargetModel: "model", Critical: true}
+
+	if _, err := scheduler.Schedule(ctx, req); err == nil {
+		t.Fatalf("Expected a scheduling error with only draining pods")
+	}
+
+	serving := &datastore.PodMetrics{
+		Pod:     datastore.Pod{NamespacedName: types.NamespacedName{Name: "serving"}},
+		Metrics: datastore.Metrics{UpdateTime: now, WaitingQueueSize: 100, KVCacheUsagePercent: 0.9},
+	}
+	pods.Store(serving.NamespacedName, serving)
+	ds = datastore.NewFakeDatastore(pods, nil, pool)
+	scheduler = NewScheduler(ds)
+	for i := 0; i < 10; i++ {
+		got, err := scheduler.Schedule(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected scheduling error: %v", err)
+		}
+		if got.NamespacedName != serving.NamespacedName {
+			t.Fatalf("Expected the request to be scheduled to %v, got %v", serving.NamespacedName, got.NamespacedName)
+		}
+	}
+}
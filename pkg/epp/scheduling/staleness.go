@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// StalenessPolicy defines how the scheduler treats pods whose metrics were not refreshed within
+// the staleness threshold, e.g. because the model server metrics endpoint hangs.
+type StalenessPolicy string
+
+const (
+	// StalenessPolicyExclude removes pods with stale metrics from the candidate set.
+	StalenessPolicyExclude StalenessPolicy = "Exclude"
+	// StalenessPolicyPenalize only considers pods with stale metrics when no pod has fresh metrics.
+	StalenessPolicyPenalize StalenessPolicy = "Penalize"
+	// StalenessPolicyAssumeFullyLoaded keeps pods with stale metrics as candidates, but treats them
+	// as fully loaded: maximum queue, full KV cache and no room to load another LoRA adapter.
+	StalenessPolicyAssumeFullyLoaded StalenessPolicy = "AssumeFullyLoaded"
+)
+
+// ParseStalenessPolicy validates the given policy name.
+func ParseStalenessPolicy(policy string) (StalenessPolicy, error) {
+	switch p := StalenessPolicy(policy); p {
+	case StalenessPolicyExclude, StalenessPolicyPenalize, StalenessPolicyAssumeFullyLoaded:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown staleness policy %q, must be one of %q, %q or %q", policy,
+			StalenessPolicyExclude, StalenessPolicyPenalize, StalenessPolicyAssumeFullyLoaded)
+	}
+}
+
+// applyStalenessPolicy returns the pods the filters should consider according to the policy.
+// The returned pods must not be mutated, since they may be shared with the datastore.
+func applyStalenessPolicy(
+	logger logr.Logger,
+	policy StalenessPolicy,
+	threshold time.Duration,
+	now time.Time,
+	pods []*datastore.PodMetrics,
+) []*datastore.PodMetrics {
+	fresh := make([]*datastore.PodMetrics, 0, len(pods))
+	stale := []*datastore.PodMetrics{}
+	for _, pod := range pods {
+		if pod.IsStale(threshold, now) {
+			stale = append(stale, pod)
+		} else {
+			fresh = append(fresh, pod)
+		}
+	}
+	if len(stale) == 0 {
+		return pods
+	}
+	logger.V(logutil.DEBUG).Info("Pods with stale metrics found", "policy", policy, "threshold", threshold, "stalePods", stale)
+
+	switch policy {
+	case StalenessPolicyExclude:
+		return fresh
+	case StalenessPolicyAssumeFullyLoaded:
+		for _, pod := range stale {
+			fresh = append(fresh, fullyLoaded(pod))
+		}
+		return fresh
+	default:
+		if len(fresh) > 0 {
+			return fresh
+		}
+		return pods
+	}
+}
+
+// fullyLoaded returns a copy of the given pod with metrics of a saturated model server.
+func fullyLoaded(pod *datastore.PodMetrics) *datastore.PodMetrics {
+	loaded := pod.Clone()
+	loaded.RunningQueueSize = math.MaxInt32
+	loaded.WaitingQueueSize = math.MaxInt32
+	loaded.KVCacheUsagePercent = 1
+	loaded.MaxActiveModels = len(loaded.ActiveModels)
+	return loaded
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+func TestApplyStalenessPolicy(t *testing.T) {
+	logger := logutil.NewTestLogger()
+	now := time.Now()
+	threshold := time.Second
+	fresh := &datastore.PodMetrics{
+		Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "fresh"}},
+		Metrics: datastore.Metrics{
+			WaitingQueueSize:    1,
+			KVCacheUsagePercent: 0.2,
+			MaxActiveModels:     2,
+			ActiveModels:        map[string]int{"foo": 1},
+			UpdateTime:          now,
+		},
+	}
+	stale := &datastore.PodMetrics{
+		Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "stale"}},
+		Metrics: datastore.Metrics{
+			WaitingQueueSize:          0,
+			KVCacheUsagePercent:       0,
+			MaxActiveModels:           2,
+			ActiveModels:              map[string]int{"bar": 1},
+			UpdateTime:                now.Add(-2 * threshold),
+			ConsecutiveScrapeFailures: 3,
+		},
+	}
+	neverScraped := &datastore.PodMetrics{
+		Pod: datastore.Pod{NamespacedName: types.NamespacedName{Name: "never-scraped"}},
+	}
+	fullyLoadedStale := &datastore.PodMetrics{
+		Pod: stale.Pod,
+		Metrics: datastore.Metrics{
+			RunningQueueSize:          math.MaxInt32,
+			WaitingQueueSize:          math.MaxInt32,
+			KVCacheUsagePercent:       1,
+			MaxActiveModels:           1,
+			ActiveModels:              map[string]int{"bar": 1},
+			UpdateTime:                stale.UpdateTime,
+			ConsecutiveScrapeFailures: 3,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		policy    StalenessPolicy
+		threshold time.Duration
+		input     []*datastore.PodMetrics
+		output    []*datastore.PodMetrics
+	}{
+		{
+			name:      "exclude stale pods",
+			policy:    StalenessPolicyExclude,
+			threshold: threshold,
+			input:     []*datastore.PodMetrics{fresh, stale, neverScraped},
+			output:    []*datastore.PodMetrics{fresh},
+		},
+		{
+			name:      "exclude all pods",
+			policy:    StalenessPolicyExclude,
+			threshold: threshold,
+			input:     []*datastore.PodMetrics{stale, neverScraped},
+			output:    []*datastore.PodMetrics{},
+		},
+		{
+			name:      "penalize stale pods when fresh pods exist",
+			policy:    StalenessPolicyPenalize,
+			threshold: threshold,
+			input:     []*datastore.PodMetrics{fresh, stale},
+			output:    []*datastore.PodMetrics{fresh},
+		},
+		{
+			name:      "penalize falls back to stale pods",
+			policy:    StalenessPolicyPenalize,
+			threshold: threshold,
+			input:     []*datastore.PodMetrics{stale, neverScraped},
+			output:    []*datastore.PodMetrics{stale, neverScraped},
+		},
+		{
+			name:      "assume stale pods are fully loaded",
+			policy:    StalenessPolicyAssumeFullyLoaded,
+			threshold: threshold,
+			input:     []*datastore.PodMetrics{fresh, stale},
+			output:    []*datastore.PodMetrics{fresh, fullyLoadedStale},
+		},
+		{
+			name:      "staleness disabled",
+			policy:    StalenessPolicyExclude,
+			threshold: 0,
+			input:     []*datastore.PodMetrics{fresh, stale, neverScraped},
+			output:    []*datastore.PodMetrics{fresh, stale, neverScraped},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := applyStalenessPolicy(logger, test.policy, test.threshold, now, test.input)
+			if diff := cmp.Diff(test.output, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+
+	// The input pods must never be mutated.
+	if stale.WaitingQueueSize != 0 || stale.KVCacheUsagePercent != 0 {
+		t.Errorf("Stale pod metrics were mutated: %+v", stale.Metrics)
+	}
+}
+
+func TestParseStalenessPolicy(t *testing.T) {
+	for _, policy := range []StalenessPolicy{StalenessPolicyExclude, StalenessPolicyPenalize, StalenessPolicyAssumeFullyLoaded} {
+		if got, err := ParseStalenessPolicy(string(policy)); err != nil || got != policy {
+			t.Errorf("ParseStalenessPolicy(%q) = %q, %v", policy, got, err)
+		}
+	}
+	if _, err := ParseStalenessPolicy("unknown"); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
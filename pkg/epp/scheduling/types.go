@@ -18,10 +18,13 @@ package scheduling
 
 // LLMRequest is a structured representation of the fields we parse out of the LLMRequest body.
 type LLMRequest struct {
-	Model string
+	Model string `json:"model"`
 	// Target models is a map of target model name to weight.
-	TargetModels map[string]int
+	TargetModels map[string]int `json:"targetModels,omitempty"`
 	// Resolved target model is the final target model after traffic split.
-	ResolvedTargetModel string
-	Critical            bool
+	ResolvedTargetModel string `json:"resolvedTargetModel"`
+	Critical            bool   `json:"critical"`
+	// EstimatedTokens is the estimated number of KV cache tokens the request needs, i.e. its prompt
+	// tokens plus the maximum number of tokens to generate. Zero means unknown.
+	EstimatedTokens int `json:"estimatedTokens,omitempty"`
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+// CacheOptions returns the options of the manager cache. Only the namespaces of the pools are
+// cached, and only the fields of the pods used by the EPP. InferenceModels are cached in all
+// namespaces, since they may reference the pools from other namespaces.
+//
+// If CachePodsByPoolSelector is set and pods are discovered from Pods, the cached pods of a
+// namespace are further restricted by the selector of its pools, if they all exist and share the
+// same selector. The pools are read with the given reader, since the cache is not started yet.
+// The selectors are recorded in PodCacheSelectors.
+func (r *ExtProcServerRunner) CacheOptions(ctx context.Context, reader client.Reader) (cache.Options, error) {
+	podNamespaces := map[string]cache.Config{}
+	for _, pool := range r.Pools {
+		podNamespaces[pool.Namespace] = cache.Config{}
+	}
+	opts := cache.Options{
+		DefaultNamespaces: podNamespaces,
+		DefaultTransform:  cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}:              {Transform: stripPod},
+			&v1alpha1.InferenceModel{}: {Namespaces: map[string]cache.Config{cache.AllNamespaces: {}}},
+		},
+	}
+	if !r.CachePodsByPoolSelector || r.EndpointDiscovery == EndpointDiscoveryEndpointSlices {
+		return opts, nil
+	}
+
+	selectors, err := r.podCacheSelectors(ctx, reader)
+	if err != nil {
+		return cache.Options{}, err
+	}
+	r.PodCacheSelectors = selectors
+	byNamespace := map[string]cache.Config{}
+	for namespace := range podNamespaces {
+		byNamespace[namespace] = cache.Config{LabelSelector: selectors[namespace]}
+	}
+	opts.ByObject[&corev1.Pod{}] = cache.ByObject{Namespaces: byNamespace, Transform: stripPod}
+	return opts, nil
+}
+
+// podCacheSelectors returns the selector shared by the pools of each namespace, if any.
+func (r *ExtProcServerRunner) podCacheSelectors(ctx context.Context, reader client.Reader) (map[string]labels.Selector, error) {
+	selectors := map[string]labels.Selector{}
+	unrestricted := map[string]bool{}
+	for _, p := range r.Pools {
+		name := p.NamespacedName
+		if unrestricted[name.Namespace] {
+			continue
+		}
+		pool := &v1alpha1.InferencePool{}
+		if err := reader.Get(ctx, name, pool); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get InferencePool %s: %w", name, err)
+			}
+			// The selector of the pool is not known yet.
+			unrestricted[name.Namespace] = true
+			continue
+		}
+		selector, err := datastore.PoolSelector(pool)
+		if err != nil || selector.Empty() {
+			unrestricted[name.Namespace] = true
+			continue
+		}
+		if existing, ok := selectors[name.Namespace]; ok && existing.String() != selector.String() {
+			// Label selectors can't express the union of the pools.
+			unrestricted[name.Namespace] = true
+			continue
+		}
+		selectors[name.Namespace] = selector
+	}
+	for namespace := range unrestricted {
+		delete(selectors, namespace)
+	}
+	return selectors, nil
+}
+
+// stripPod removes the fields of the pods not used by the EPP before they are cached.
+func stripPod(obj any) (any, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return obj, nil
+	}
+	return &corev1.Pod{
+		TypeMeta: pod.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              pod.Name,
+			Namespace:         pod.Namespace,
+			UID:               pod.UID,
+			ResourceVersion:   pod.ResourceVersion,
+			CreationTimestamp: pod.CreationTimestamp,
+			DeletionTimestamp: pod.DeletionTimestamp,
+			Labels:            pod.Labels,
+			Annotations:       pod.Annotations,
+		},
+		Status: corev1.PodStatus{
+			PodIP:      pod.Status.PodIP,
+			PodIPs:     pod.Status.PodIPs,
+			Conditions: pod.Status.Conditions,
+		},
+	}, nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+func TestCacheOptions(t *testing.T) {
+	pool := func(namespace, name, app string) *v1alpha1.InferencePool {
+		return &v1alpha1.InferencePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.InferencePoolSpec{
+				Selector:         map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": v1alpha1.LabelValue(app)},
+				TargetPortNumber: 8000,
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		served            []types.NamespacedName
+		objs              []client.Object
+		disabled          bool
+		endpointSlices    bool
+		wantPodSelectors  map[string]string
+		wantPodNamespaces []string
+	}{
+		{
+			name:              "Single pool",
+			served:            []types.NamespacedName{{Namespace: "ns1", Name: "pool1"}},
+			objs:              []client.Object{pool("ns1", "pool1", "vllm")},
+			wantPodSelectors:  map[string]string{"ns1": "app=vllm"},
+			wantPodNamespaces: []string{"ns1"},
+		},
+		{
+			name: "Pools sharing a selector",
+			served: []types.NamespacedName{
+				{Namespace: "ns1", Name: "pool1"}, {Namespace: "ns1", Name: "pool2"}, {Namespace: "ns2", Name: "pool3"},
+			},
+			objs: []client.Object{
+				pool("ns1", "pool1", "vllm"), pool("ns1", "pool2", "vllm"), pool("ns2", "pool3", "other"),
+			},
+			wantPodSelectors:  map[string]string{"ns1": "app=vllm", "ns2": "app=other"},
+			wantPodNamespaces: []string{"ns1", "ns2"},
+		},
+		{
+			name:              "Pools with different selectors",
+			served:            []types.NamespacedName{{Namespace: "ns1", Name: "pool1"}, {Namespace: "ns1", Name: "pool2"}},
+			objs:              []client.Object{pool("ns1", "pool1", "vllm"), pool("ns1", "pool2", "other")},
+			wantPodSelectors:  map[string]string{},
+			wantPodNamespaces: []string{"ns1"},
+		},
+		{
+			name:              "Missing pool",
+			served:            []types.NamespacedName{{Namespace: "ns1", Name: "pool1"}, {Namespace: "ns1", Name: "pool2"}},
+			objs:              []client.Object{pool("ns1", "pool1", "vllm")},
+			wantPodSelectors:  map[string]string{},
+			wantPodNamespaces: []string{"ns1"},
+		},
+		{
+			name:              "Disabled",
+			served:            []types.NamespacedName{{Namespace: "ns1", Name: "pool1"}},
+			objs:              []client.Object{pool("ns1", "pool1", "vllm")},
+			disabled:          true,
+			wantPodNamespaces: []string{"ns1"},
+		},
+		{
+			name:              "EndpointSlices",
+			served:            []types.NamespacedName{{Namespace: "ns1", Name: "pool1"}},
+			objs:              []client.Object{pool("ns1", "pool1", "vllm")},
+			endpointSlices:    true,
+			wantPodNamespaces: []string{"ns1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.AddToScheme(scheme)
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(test.objs...).Build()
+
+			runner := NewDefaultExtProcServerRunner()
+			runner.CachePodsByPoolSelector = !test.disabled
+			if test.endpointSlices {
+				runner.EndpointDiscovery = EndpointDiscoveryEndpointSlices
+			}
+			for _, name := range test.served {
+				runner.Pools = append(runner.Pools, &Pool{NamespacedName: name})
+			}
+			opts, err := runner.CacheOptions(context.Background(), reader)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var gotNamespaces []string
+			for namespace := range opts.DefaultNamespaces {
+				gotNamespaces = append(gotNamespaces, namespace)
+			}
+			if diff := cmp.Diff(test.wantPodNamespaces, gotNamespaces, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected namespaces (-want +got): %s", diff)
+			}
+			var gotSelectors map[string]string
+			if runner.PodCacheSelectors != nil {
+				gotSelectors = map[string]string{}
+				for namespace, selector := range runner.PodCacheSelectors {
+					gotSelectors[namespace] = selector.String()
+				}
+			}
+			if diff := cmp.Diff(test.wantPodSelectors, gotSelectors); diff != "" {
+				t.Errorf("Unexpected pod cache selectors (-want +got): %s", diff)
+			}
+			for obj, byObject := range opts.ByObject {
+				if _, ok := obj.(*v1alpha1.InferenceModel); ok {
+					if _, ok := byObject.Namespaces[cache.AllNamespaces]; !ok {
+						t.Errorf("Expected InferenceModels to be cached in all namespaces, got %v", byObject.Namespaces)
+					}
+				}
+				if _, ok := obj.(*corev1.Pod); !ok {
+					continue
+				}
+				for namespace, config := range byObject.Namespaces {
+					want, ok := test.wantPodSelectors[namespace]
+					if got := config.LabelSelector; (got != nil) != ok || (ok && got.String() != want) {
+						t.Errorf("Unexpected pod label selector in namespace %s: %v", namespace, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStripPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "pod",
+			Namespace:     "default",
+			Labels:        map[string]string{"app": "vllm"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vllm"}}},
+		Status: corev1.PodStatus{
+			PodIP:             "10.0.0.1",
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "vllm", RestartCount: 3}},
+		},
+	}
+	want := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "vllm"},
+		},
+		Status: corev1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	got, err := stripPod(pod)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected pod (-want +got): %s", diff)
+	}
+}
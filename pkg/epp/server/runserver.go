@@ -26,12 +26,15 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"github.com/go-logr/logr"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/controller"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
 )
 
@@ -47,76 +51,210 @@ import (
 type ExtProcServerRunner struct {
 	GrpcPort                         int
 	TargetEndpointKey                string
-	PoolName                         string
-	PoolNamespace                    string
+	PoolSelectorHeader               string
+	Pools                            []*Pool
+	EndpointDiscovery                string
 	RefreshMetricsInterval           time.Duration
 	RefreshPrometheusMetricsInterval time.Duration
-	Datastore                        datastore.Datastore
-	Provider                         *backend.Provider
+	MetricsStalenessThreshold        time.Duration
+	RefreshModelsInterval            time.Duration
+	PoolStatusInterval               time.Duration
+	PoolStatusMinReadyPods           int
+	PoolStatusMinFreshMetricsRatio   float64
 	SecureServing                    bool
-	CertPath                         string
+	// CertPath is the directory of the tls.crt and tls.key files of the serving certificate, which
+	// are reloaded on change.
+	CertPath string
+	// ClientCAFile is the CA bundle verifying the client certificates, which is reloaded on change.
+	// Clients are not required to present a certificate if not set.
+	ClientCAFile string
+	// CertReloadInterval is the interval at which the certificate files are reread, in addition to
+	// file change notifications. DefaultCertReloadInterval is used if not positive.
+	CertReloadInterval      time.Duration
+	CachePodsByPoolSelector bool
+	// ShutdownDrainPeriod is how long the ext_proc server keeps accepting new streams once the
+	// shutdown started and the server is reported as not ready, see ShuttingDown.
+	ShutdownDrainPeriod time.Duration
+	// ShutdownTimeout is how long the in-flight streams are given to complete after the drain
+	// period, before they are cancelled.
+	ShutdownTimeout time.Duration
+	// SchedulerConfig is the config of the schedulers of the pools. Its MetricsStalenessThreshold
+	// is replaced by the MetricsStalenessThreshold of the runner. It can be changed at runtime with
+	// UpdateSchedulerConfig.
+	SchedulerConfig scheduling.Config
+	// DecisionRecorder records the scheduling decisions of all pools, if not nil.
+	DecisionRecorder scheduling.DecisionRecorder
+	// PodCacheSelectors are the selectors restricting the cached pods per namespace, set by
+	// CacheOptions.
+	PodCacheSelectors map[string]labels.Selector
+
+	schedulersMu sync.Mutex
+	schedulers   map[types.NamespacedName]*scheduling.Scheduler
+	shuttingDown atomic.Bool
+}
+
+// Pool holds the components serving a single InferencePool.
+type Pool struct {
+	types.NamespacedName
+	Datastore   datastore.Datastore
+	Provider    *backend.Provider
+	ModelProber *backend.ModelProber // nil disables model discovery
 }
 
 // Default values for CLI flags in main
 const (
-	DefaultGrpcPort                         = 9002                             // default for --grpcPort
-	DefaultTargetEndpointKey                = "x-gateway-destination-endpoint" // default for --targetEndpointKey
-	DefaultPoolName                         = ""                               // required but no default
-	DefaultPoolNamespace                    = "default"                        // default for --poolNamespace
-	DefaultRefreshMetricsInterval           = 50 * time.Millisecond            // default for --refreshMetricsInterval
-	DefaultRefreshPrometheusMetricsInterval = 5 * time.Second                  // default for --refreshPrometheusMetricsInterval
-	DefaultSecureServing                    = true                             // default for --secureServing
+	DefaultGrpcPort                         = 9002                                        // default for --grpcPort
+	DefaultTargetEndpointKey                = "x-gateway-destination-endpoint"            // default for --targetEndpointKey
+	DefaultPoolSelectorHeader               = "x-gateway-inference-pool"                  // default for --poolSelectorHeader
+	DefaultEndpointDiscovery                = EndpointDiscoveryPods                       // default for --endpointDiscovery
+	DefaultPoolName                         = ""                                          // required but no default
+	DefaultPoolNamespace                    = "default"                                   // default for --poolNamespace
+	DefaultRefreshMetricsInterval           = 50 * time.Millisecond                       // default for --refreshMetricsInterval
+	DefaultRefreshPrometheusMetricsInterval = 5 * time.Second                             // default for --refreshPrometheusMetricsInterval
+	DefaultMetricsStalenessThreshold        = scheduling.DefaultMetricsStalenessThreshold // default for --metricsStalenessThreshold
+	DefaultRefreshModelsInterval            = 30 * time.Second                            // default for --refreshModelsInterval
+	DefaultPoolStatusInterval               = 10 * time.Second                            // default for --poolStatusInterval
+	DefaultPoolStatusMinReadyPods           = 1                                           // default for --poolStatusMinReadyPods
+	DefaultPoolStatusMinFreshMetricsRatio   = 0.5                                         // default for --poolStatusMinFreshMetricsRatio
+	DefaultSecureServing                    = true                                        // default for --secureServing
+	DefaultCachePodsByPoolSelector          = true                                        // default for --cachePodsByPoolSelector
+	DefaultShutdownDrainPeriod              = 5 * time.Second                             // default for --shutdownDrainPeriod
+	DefaultShutdownTimeout                  = 20 * time.Second                            // default for --shutdownTimeout
+)
+
+// Endpoint discovery modes
+const (
+	// EndpointDiscoveryPods discovers the model server pods by watching the Pods selected by the pools.
+	EndpointDiscoveryPods = "Pods"
+	// EndpointDiscoveryEndpointSlices discovers the model server pods by watching the EndpointSlices
+	// of the Services backing the pools, see datastore.ServiceAnnotation.
+	EndpointDiscoveryEndpointSlices = "EndpointSlices"
 )
 
 func NewDefaultExtProcServerRunner() *ExtProcServerRunner {
 	return &ExtProcServerRunner{
 		GrpcPort:                         DefaultGrpcPort,
 		TargetEndpointKey:                DefaultTargetEndpointKey,
-		PoolName:                         DefaultPoolName,
-		PoolNamespace:                    DefaultPoolNamespace,
+		PoolSelectorHeader:               DefaultPoolSelectorHeader,
+		EndpointDiscovery:                DefaultEndpointDiscovery,
 		RefreshMetricsInterval:           DefaultRefreshMetricsInterval,
 		RefreshPrometheusMetricsInterval: DefaultRefreshPrometheusMetricsInterval,
+		MetricsStalenessThreshold:        DefaultMetricsStalenessThreshold,
+		RefreshModelsInterval:            DefaultRefreshModelsInterval,
+		PoolStatusInterval:               DefaultPoolStatusInterval,
+		PoolStatusMinReadyPods:           DefaultPoolStatusMinReadyPods,
+		PoolStatusMinFreshMetricsRatio:   DefaultPoolStatusMinFreshMetricsRatio,
 		SecureServing:                    DefaultSecureServing,
-		// Datastore can be assigned later.
+		CachePodsByPoolSelector:          DefaultCachePodsByPoolSelector,
+		ShutdownDrainPeriod:              DefaultShutdownDrainPeriod,
+		ShutdownTimeout:                  DefaultShutdownTimeout,
+		SchedulerConfig:                  scheduling.DefaultConfig(),
+		// Pools can be assigned later.
 	}
 }
 
+// ShuttingDown returns true once the shutdown of the ext_proc server started. The server is not
+// ready anymore then, though it keeps serving during the drain period.
+func (r *ExtProcServerRunner) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Datastores returns the datastores of the pools served by the runner.
+func (r *ExtProcServerRunner) Datastores() datastore.Pools {
+	pools := datastore.Pools{}
+	for _, pool := range r.Pools {
+		pools[pool.NamespacedName] = pool.Datastore
+	}
+	return pools
+}
+
+// Schedulers returns the schedulers of the pools, which are created with the SchedulerConfig on
+// first use. The returned map must not be modified.
+func (r *ExtProcServerRunner) Schedulers() map[types.NamespacedName]*scheduling.Scheduler {
+	r.schedulersMu.Lock()
+	defer r.schedulersMu.Unlock()
+	if r.schedulers == nil {
+		r.schedulers = make(map[types.NamespacedName]*scheduling.Scheduler, len(r.Pools))
+		for _, pool := range r.Pools {
+			scheduler := scheduling.NewSchedulerWithConfig(pool.Datastore, r.schedulerConfig())
+			if r.DecisionRecorder != nil {
+				scheduler.SetDecisionRecorder(r.DecisionRecorder)
+			}
+			r.schedulers[pool.NamespacedName] = scheduler
+		}
+	}
+	return r.schedulers
+}
+
+// UpdateSchedulerConfig replaces the SchedulerConfig of the runner, and applies it to the
+// schedulers of the pools if they were created.
+func (r *ExtProcServerRunner) UpdateSchedulerConfig(config scheduling.Config) {
+	r.schedulersMu.Lock()
+	defer r.schedulersMu.Unlock()
+	r.SchedulerConfig = config
+	for _, scheduler := range r.schedulers {
+		scheduler.SetConfig(r.schedulerConfig())
+	}
+}
+
+// schedulerConfig returns the config of the schedulers. schedulersMu must be held.
+func (r *ExtProcServerRunner) schedulerConfig() scheduling.Config {
+	config := r.SchedulerConfig
+	config.MetricsStalenessThreshold = r.MetricsStalenessThreshold
+	return config
+}
+
 // SetupWithManager sets up the runner with the given manager.
 func (r *ExtProcServerRunner) SetupWithManager(mgr ctrl.Manager) error {
+	pools := r.Datastores()
+
 	// Create the controllers and register them with the manager
+	endpointSlices := r.EndpointDiscovery == EndpointDiscoveryEndpointSlices
 	if err := (&controller.InferencePoolReconciler{
-		Datastore: r.Datastore,
-		Scheme:    mgr.GetScheme(),
-		Client:    mgr.GetClient(),
-		PoolNamespacedName: types.NamespacedName{
-			Name:      r.PoolName,
-			Namespace: r.PoolNamespace,
+		Pools:             pools,
+		Scheme:            mgr.GetScheme(),
+		Client:            mgr.GetClient(),
+		Record:            mgr.GetEventRecorderFor("InferencePool"),
+		EndpointSlices:    endpointSlices,
+		PodCacheSelectors: r.PodCacheSelectors,
+		PoolStatus: controller.PoolStatusConfig{
+			Interval:                  r.PoolStatusInterval,
+			MinReadyPods:              r.PoolStatusMinReadyPods,
+			MinFreshMetricsRatio:      r.PoolStatusMinFreshMetricsRatio,
+			MetricsStalenessThreshold: r.MetricsStalenessThreshold,
 		},
-		Record: mgr.GetEventRecorderFor("InferencePool"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed setting up InferencePoolReconciler: %w", err)
 	}
 
 	if err := (&controller.InferenceModelReconciler{
-		Datastore: r.Datastore,
-		Scheme:    mgr.GetScheme(),
-		Client:    mgr.GetClient(),
-		PoolNamespacedName: types.NamespacedName{
-			Name:      r.PoolName,
-			Namespace: r.PoolNamespace,
-		},
+		Pools:  pools,
+		Scheme: mgr.GetScheme(),
+		Client: mgr.GetClient(),
 		Record: mgr.GetEventRecorderFor("InferenceModel"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed setting up InferenceModelReconciler: %w", err)
 	}
 
+	if endpointSlices {
+		if err := (&controller.EndpointSliceReconciler{
+			Pools:  pools,
+			Scheme: mgr.GetScheme(),
+			Client: mgr.GetClient(),
+			Record: mgr.GetEventRecorderFor("endpointslice"),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed setting up EndpointSliceReconciler: %v", err)
+		}
+		return nil
+	}
+
 	if err := (&controller.PodReconciler{
-		Datastore: r.Datastore,
-		Scheme:    mgr.GetScheme(),
-		Client:    mgr.GetClient(),
-		Record:    mgr.GetEventRecorderFor("pod"),
+		Pools:  pools,
+		Scheme: mgr.GetScheme(),
+		Client: mgr.GetClient(),
+		Record: mgr.GetEventRecorderFor("pod"),
 	}).SetupWithManager(mgr); err != nil {
-		return fmt.Errorf("failed setting up EndpointSliceReconciler: %v", err)
+		return fmt.Errorf("failed setting up PodReconciler: %v", err)
 	}
 	return nil
 }
@@ -125,42 +263,59 @@ func (r *ExtProcServerRunner) SetupWithManager(mgr ctrl.Manager) error {
 // The runnable implements LeaderElectionRunnable with leader election disabled.
 func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 	return runnable.NoLeaderElection(manager.RunnableFunc(func(ctx context.Context) error {
-		// Initialize backend provider
-		if err := r.Provider.Init(ctx, r.RefreshMetricsInterval, r.RefreshPrometheusMetricsInterval); err != nil {
-			logger.Error(err, "Failed to initialize backend provider")
-			return err
+		// The metrics of the pods keep being refreshed until the in-flight requests are drained.
+		backendCtx, cancelBackend := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancelBackend()
+
+		schedulers := r.Schedulers()
+		pools := make(map[types.NamespacedName]*handlers.Pool, len(r.Pools))
+		for _, pool := range r.Pools {
+			// Initialize backend provider
+			if err := pool.Provider.Init(backendCtx, r.RefreshMetricsInterval, r.RefreshPrometheusMetricsInterval, r.MetricsStalenessThreshold); err != nil {
+				logger.Error(err, "Failed to initialize backend provider", "pool", pool.NamespacedName)
+				return err
+			}
+			if pool.ModelProber != nil {
+				if err := pool.ModelProber.Init(backendCtx, r.RefreshModelsInterval); err != nil {
+					logger.Error(err, "Failed to initialize model prober", "pool", pool.NamespacedName)
+					return err
+				}
+			}
+			pools[pool.NamespacedName] = &handlers.Pool{
+				Datastore: pool.Datastore,
+				Scheduler: schedulers[pool.NamespacedName],
+			}
 		}
 
 		var srv *grpc.Server
 		if r.SecureServing {
-			var cert tls.Certificate
-			var err error
-			if r.CertPath != "" {
-				cert, err = tls.LoadX509KeyPair(r.CertPath+"/tls.crt", r.CertPath+"/tls.key")
-			} else {
-				// Create tls based credential.
-				cert, err = createSelfSignedTLSCertificate(logger)
-			}
+			tlsConfig, err := r.serverTLSConfig(ctx, logger)
 			if err != nil {
-				logger.Error(err, "Failed to create self signed certificate")
+				logger.Error(err, "Failed to create the TLS config")
 				return err
 			}
-
-			creds := credentials.NewTLS(&tls.Config{
-				Certificates: []tls.Certificate{cert},
-			})
 			// Init the server.
-			srv = grpc.NewServer(grpc.Creds(creds))
+			srv = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
 		} else {
 			srv = grpc.NewServer()
 		}
-		extProcPb.RegisterExternalProcessorServer(
-			srv,
-			handlers.NewServer(scheduling.NewScheduler(r.Datastore), r.TargetEndpointKey, r.Datastore),
-		)
+		extProcServer := handlers.NewMultiPoolServer(pools, r.TargetEndpointKey, r.PoolSelectorHeader)
+		extProcPb.RegisterExternalProcessorServer(srv, extProcServer)
 
 		// Forward to the gRPC runnable.
-		return runnable.GRPCServer("ext-proc", srv, r.GrpcPort).Start(ctx)
+		return runnable.GRPCServerWithShutdown("ext-proc", srv, r.GrpcPort, runnable.GRPCShutdown{
+			OnShutdown: func() {
+				r.shuttingDown.Store(true)
+				logger.Info("Draining ext-proc streams", "active", extProcServer.ActiveStreams())
+			},
+			DrainPeriod: r.ShutdownDrainPeriod,
+			Timeout:     r.ShutdownTimeout,
+			OnTimeout: func() {
+				cutOff := extProcServer.ActiveStreams()
+				logger.Info("Cancelling ext-proc streams not completed within the shutdown timeout", "count", cutOff)
+				metrics.RecordShutdownCutOffStreams(cutOff)
+			},
+		}).Start(ctx)
 	}))
 }
 
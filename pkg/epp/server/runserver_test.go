@@ -17,10 +17,19 @@ limitations under the License.
 package server_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -36,3 +45,101 @@ func TestRunnable(t *testing.T) {
 		t.Error("runner returned NeedLeaderElection = true, expected false")
 	}
 }
+
+func TestGracefulShutdown(t *testing.T) {
+	metrics.Register()
+
+	tests := []struct {
+		name string
+		// completeStream completes the in-flight stream once the shutdown started.
+		completeStream bool
+		wantCutOff     int
+	}{
+		{
+			name:           "stream completed",
+			completeStream: true,
+		},
+		{
+			name:       "stream cut off",
+			wantCutOff: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cutOffBefore := cutOffStreams(t)
+			runner := server.NewDefaultExtProcServerRunner()
+			runner.SecureServing = false
+			runner.GrpcPort = freePort(t)
+			runner.ShutdownDrainPeriod = 200 * time.Millisecond
+			runner.ShutdownTimeout = 200 * time.Millisecond
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() {
+				done <- runner.AsRunnable(logutil.NewTestLogger()).Start(ctx)
+			}()
+
+			conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", runner.GrpcPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			client := extProcPb.NewExternalProcessorClient(conn)
+			streamCtx, cancelStream := context.WithCancel(context.Background())
+			defer cancelStream()
+			var stream extProcPb.ExternalProcessor_ProcessClient
+			eventually(t, "Stream not opened", func() error {
+				stream, err = client.Process(streamCtx, grpc.WaitForReady(true))
+				if err != nil {
+					return err
+				}
+				if err := stream.Send(&extProcPb.ProcessingRequest{
+					Request: &extProcPb.ProcessingRequest_RequestHeaders{RequestHeaders: &extProcPb.HttpHeaders{}},
+				}); err != nil {
+					return err
+				}
+				// The stream is in flight once the server responded to its headers.
+				_, err := stream.Recv()
+				return err
+			})
+
+			cancel()
+			eventually(t, "Shutdown not started", func() error {
+				if !runner.ShuttingDown() {
+					return errors.New("not shutting down")
+				}
+				return nil
+			})
+			if test.completeStream {
+				if err := stream.CloseSend(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Unexpected server error: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the server to stop")
+			}
+			if got := cutOffStreams(t) - cutOffBefore; got != test.wantCutOff {
+				t.Errorf("Unexpected cut off streams, got %v, want %v", got, test.wantCutOff)
+			}
+		})
+	}
+}
+
+func cutOffStreams(t *testing.T) int {
+	t.Helper()
+	families, err := legacyregistry.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() == "inference_extension_shutdown_cut_off_streams_total" {
+			return int(f.GetMetric()[0].GetCounter().GetValue())
+		}
+	}
+	return 0
+}
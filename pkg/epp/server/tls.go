@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+)
+
+const (
+	// DefaultCertReloadInterval is the default interval at which the serving certificate and the
+	// client CA bundle are reread.
+	DefaultCertReloadInterval = 10 * time.Second
+
+	certFileName = "tls.crt"
+	keyFileName  = "tls.key"
+)
+
+// serverTLSConfig returns the TLS config of the ext_proc server. The certificate in CertPath and
+// the client CA bundle of ClientCAFile are reloaded until the context is done, new handshakes use
+// the rotated files. A self-signed certificate is generated if CertPath is not set.
+func (r *ExtProcServerRunner) serverTLSConfig(ctx context.Context, logger logr.Logger) (*tls.Config, error) {
+	interval := r.CertReloadInterval
+	if interval <= 0 {
+		interval = DefaultCertReloadInterval
+	}
+
+	cfg := &tls.Config{}
+	if r.CertPath != "" {
+		cw, err := certwatcher.New(filepath.Join(r.CertPath, certFileName), filepath.Join(r.CertPath, keyFileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the serving certificate: %w", err)
+		}
+		cw.WithWatchInterval(interval)
+		cw.RegisterCallback(func(tls.Certificate) {
+			logger.Info("Serving certificate loaded", "path", r.CertPath)
+		})
+		go func() {
+			if err := cw.Start(ctx); err != nil {
+				logger.Error(err, "Failed to watch the serving certificate", "path", r.CertPath)
+			}
+		}()
+		cfg.GetCertificate = cw.GetCertificate
+	} else {
+		cert, err := createSelfSignedTLSCertificate(logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create self signed certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if r.ClientCAFile == "" {
+		return cfg, nil
+	}
+	ca := &caBundle{path: r.ClientCAFile}
+	if _, err := ca.load(); err != nil {
+		return nil, err
+	}
+	go wait.UntilWithContext(ctx, func(context.Context) {
+		if changed, err := ca.load(); err != nil {
+			logger.Error(err, "Failed to reload the client CA bundle", "path", r.ClientCAFile)
+		} else if changed {
+			logger.Info("Client CA bundle reloaded", "path", r.ClientCAFile)
+		}
+	}, interval)
+
+	// Only clients with a certificate signed by the CA bundle can connect.
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c := cfg.Clone()
+		c.GetConfigForClient = nil
+		c.ClientCAs = ca.pool.Load()
+		return c, nil
+	}
+	return cfg, nil
+}
+
+// caBundle is a CA bundle file, reloaded when its content changes.
+type caBundle struct {
+	path string
+	data []byte
+	pool atomic.Pointer[x509.CertPool]
+}
+
+// load reads the bundle, and returns true if its content changed. The previous certificates are
+// kept if the bundle cannot be read or parsed.
+func (b *caBundle) load() (bool, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the client CA bundle: %w", err)
+	}
+	if bytes.Equal(data, b.data) {
+		return false, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return false, fmt.Errorf("no certificate found in the client CA bundle %s", b.path)
+	}
+	b.data = data
+	b.pool.Store(pool)
+	return true, nil
+}
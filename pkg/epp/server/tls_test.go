@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// testCA issues certificates for the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM encoded certificate and key of the given serial number.
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: fmt.Sprintf("test-%d", serial)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *testCA) clientCert(t *testing.T, serial int64) *tls.Certificate {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, serial, x509.ExtKeyUsageClientAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cert
+}
+
+// writeServingCert writes the serving certificate of the given serial number to the directory,
+// replacing the files atomically.
+func writeServingCert(t *testing.T, ca *testCA, dir string, serial int64) {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, serial, x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "tls.key"), keyPEM)
+	writeFile(t, filepath.Join(dir, "tls.crt"), certPEM)
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+// startServer starts the ext_proc server of the runner, and returns its address.
+func startServer(t *testing.T, runner *server.ExtProcServerRunner) string {
+	t.Helper()
+	runner.GrpcPort = freePort(t)
+	runner.ShutdownDrainPeriod = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- runner.AsRunnable(logutil.NewTestLogger()).Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Unexpected server error: %v", err)
+		}
+	})
+	return fmt.Sprintf("127.0.0.1:%d", runner.GrpcPort)
+}
+
+// connect opens a TLS connection to the server, and returns the serving certificate. The server
+// may reject the client certificate after the handshake, the connection is used until the server
+// sends its HTTP/2 settings.
+func connect(addr string, clientCert *tls.Certificate) (*x509.Certificate, error) {
+	// The serving certificate is checked by the tests. gRPC requires the HTTP/2 protocol to be
+	// negotiated.
+	cfg := &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}}
+	if clientCert != nil {
+		cfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", addr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+// eventually calls f until it succeeds or the timeout expires.
+func eventually(t *testing.T, msg string, f func() error) {
+	t.Helper()
+	var err error
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(50 * time.Millisecond) {
+		if err = f(); err == nil {
+			return
+		}
+	}
+	t.Fatalf("%s: %v", msg, err)
+}
+
+func TestServingCertRotation(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	writeServingCert(t, ca, dir, 10)
+
+	runner := server.NewDefaultExtProcServerRunner()
+	runner.CertPath = dir
+	runner.CertReloadInterval = 100 * time.Millisecond
+	addr := startServer(t, runner)
+
+	expectSerial := func(want int64) func() error {
+		return func() error {
+			cert, err := connect(addr, nil)
+			if err != nil {
+				return err
+			}
+			if cert.SerialNumber.Int64() != want {
+				return fmt.Errorf("unexpected serial number %v, want %v", cert.SerialNumber, want)
+			}
+			return nil
+		}
+	}
+	eventually(t, "Initial certificate not served", expectSerial(10))
+
+	writeServingCert(t, ca, dir, 11)
+	eventually(t, "Rotated certificate not served", expectSerial(11))
+}
+
+func TestClientCertVerification(t *testing.T) {
+	ca, otherCA := newTestCA(t), newTestCA(t)
+	dir := t.TempDir()
+	writeServingCert(t, ca, dir, 10)
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, ca.pem)
+
+	runner := server.NewDefaultExtProcServerRunner()
+	runner.CertPath = dir
+	runner.ClientCAFile = caFile
+	runner.CertReloadInterval = 100 * time.Millisecond
+	addr := startServer(t, runner)
+
+	trusted, untrusted := ca.clientCert(t, 20), otherCA.clientCert(t, 21)
+	eventually(t, "Trusted client rejected", func() error {
+		_, err := connect(addr, trusted)
+		return err
+	})
+	if _, err := connect(addr, nil); err == nil {
+		t.Error("Client without certificate accepted")
+	}
+	if _, err := connect(addr, untrusted); err == nil {
+		t.Error("Client with untrusted certificate accepted")
+	}
+
+	// Rotate the CA bundle.
+	writeFile(t, caFile, otherCA.pem)
+	eventually(t, "Client trusted by the rotated CA bundle rejected", func() error {
+		_, err := connect(addr, untrusted)
+		return err
+	})
+	if _, err := connect(addr, trusted); err == nil {
+		t.Error("Client with certificate of the previous CA bundle accepted")
+	}
+}
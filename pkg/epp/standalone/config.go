@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package standalone runs the EPP without Kubernetes: the InferencePools, InferenceModels and model
+// server endpoints are read from a local file instead of the API server.
+package standalone
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultNamespace is the namespace of the pools of the config that do not set one.
+const DefaultNamespace = "default"
+
+// Config is the content of the standalone config file, e.g.:
+//
+//	pools:
+//	- inferencePool:
+//	    metadata:
+//	      name: vllm-llama2-7b-pool
+//	    spec:
+//	      targetPortNumber: 8000
+//	  inferenceModels:
+//	  - metadata:
+//	      name: tweet-summary
+//	    spec:
+//	      modelName: tweet-summary
+//	      criticality: Critical
+//	  endpoints:
+//	  - name: gpu-0
+//	    address: 10.0.0.1
+type Config struct {
+	Pools []PoolConfig `json:"pools"`
+}
+
+// PoolConfig holds an InferencePool with its InferenceModels and model server endpoints.
+type PoolConfig struct {
+	// InferencePool is the pool. Its namespace defaults to DefaultNamespace, and its selector is
+	// not used since the endpoints are listed explicitly.
+	InferencePool v1alpha1.InferencePool `json:"inferencePool"`
+	// InferenceModels are the models served by the pool. Their namespace and poolRef default to the
+	// namespace and name of the pool.
+	InferenceModels []v1alpha1.InferenceModel `json:"inferenceModels,omitempty"`
+	// Endpoints are the model servers of the pool, stored in the datastore as pods in the namespace
+	// of the pool.
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// Endpoint is a model server listening on the target port of its pool.
+type Endpoint struct {
+	// Name identifies the endpoint within the pool, it is used as the pod name.
+	Name string `json:"name"`
+	// Address is the IPv4 or IPv6 address of the model server. Several model servers on the same
+	// host need distinct addresses, e.g. loopback addresses like 127.0.0.2.
+	Address string `json:"address"`
+	// Draining stops routing new requests to the endpoint, see datastore.DrainAnnotation.
+	Draining bool `json:"draining,omitempty"`
+}
+
+// NamespacedName returns the name of the pool.
+func (p *PoolConfig) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: p.InferencePool.Namespace, Name: p.InferencePool.Name}
+}
+
+// Parse parses and validates the given config file content and applies the defaults. Unknown
+// fields are rejected to catch typos.
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse standalone config: %w", err)
+	}
+	pools := map[types.NamespacedName]bool{}
+	for i := range cfg.Pools {
+		pool := &cfg.Pools[i]
+		if err := pool.defaultAndValidate(); err != nil {
+			return nil, fmt.Errorf("invalid pool %d of standalone config: %w", i, err)
+		}
+		if pools[pool.NamespacedName()] {
+			return nil, fmt.Errorf("duplicate InferencePool %s in standalone config", pool.NamespacedName())
+		}
+		pools[pool.NamespacedName()] = true
+	}
+	return cfg, nil
+}
+
+func (p *PoolConfig) defaultAndValidate() error {
+	pool := &p.InferencePool
+	if pool.Name == "" {
+		return fmt.Errorf("InferencePool name must be set")
+	}
+	if pool.Namespace == "" {
+		pool.Namespace = DefaultNamespace
+	}
+	if pool.Spec.TargetPortNumber < 1 || pool.Spec.TargetPortNumber > 65535 {
+		return fmt.Errorf("InferencePool %s targetPortNumber %d must be between 1 and 65535", p.NamespacedName(), pool.Spec.TargetPortNumber)
+	}
+
+	modelNames := map[string]bool{}
+	for i := range p.InferenceModels {
+		model := &p.InferenceModels[i]
+		if model.Name == "" {
+			return fmt.Errorf("InferenceModel %d name must be set", i)
+		}
+		if model.Namespace == "" {
+			model.Namespace = pool.Namespace
+		}
+		if model.Namespace != pool.Namespace {
+			return fmt.Errorf("InferenceModel %s must be in the namespace of its pool %s", model.Name, p.NamespacedName())
+		}
+		if model.Spec.PoolRef.Name == "" {
+			model.Spec.PoolRef.Name = pool.Name
+		}
+		if model.Spec.PoolRef.Name != pool.Name || datastore.PoolRefName(model).Namespace != pool.Namespace {
+			return fmt.Errorf("InferenceModel %s poolRef %q must reference its pool %s", model.Name, model.Spec.PoolRef.Name, p.NamespacedName())
+		}
+		if errs := datastore.ValidateInferenceModel(model); len(errs) > 0 {
+			return fmt.Errorf("invalid InferenceModel %s: %w", model.Name, errs.ToAggregate())
+		}
+		if modelNames[model.Spec.ModelName] {
+			return fmt.Errorf("duplicate modelName %q in pool %s", model.Spec.ModelName, p.NamespacedName())
+		}
+		modelNames[model.Spec.ModelName] = true
+	}
+
+	endpointNames := map[string]bool{}
+	for _, ep := range p.Endpoints {
+		if ep.Name == "" {
+			return fmt.Errorf("endpoint name must be set in pool %s", p.NamespacedName())
+		}
+		if endpointNames[ep.Name] {
+			return fmt.Errorf("duplicate endpoint %q in pool %s", ep.Name, p.NamespacedName())
+		}
+		endpointNames[ep.Name] = true
+		if net.ParseIP(ep.Address) == nil {
+			return fmt.Errorf("endpoint %q address %q must be an IP address", ep.Name, ep.Address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name: "Defaults",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: model
+    spec:
+      modelName: llama
+  endpoints:
+  - name: gpu-0
+    address: 10.0.0.1
+  - name: gpu-1
+    address: fd00::1
+    draining: true
+`,
+			want: &Config{Pools: []PoolConfig{{
+				InferencePool: v1alpha1.InferencePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+					Spec:       v1alpha1.InferencePoolSpec{TargetPortNumber: 8000},
+				},
+				InferenceModels: []v1alpha1.InferenceModel{{
+					ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+					Spec:       v1alpha1.InferenceModelSpec{ModelName: "llama", PoolRef: v1alpha1.PoolObjectReference{Name: "pool"}},
+				}},
+				Endpoints: []Endpoint{
+					{Name: "gpu-0", Address: "10.0.0.1"},
+					{Name: "gpu-1", Address: "fd00::1", Draining: true},
+				},
+			}}},
+		},
+		{
+			name: "Unknown field",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  endpoint:
+  - name: gpu-0
+`,
+			wantErr: "unknown field",
+		},
+		{
+			name: "Missing target port",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+`,
+			wantErr: "targetPortNumber",
+		},
+		{
+			name: "Duplicate pools",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+- inferencePool:
+    metadata:
+      name: pool
+      namespace: default
+    spec:
+      targetPortNumber: 8000
+`,
+			wantErr: "duplicate InferencePool",
+		},
+		{
+			name: "Model referencing another pool",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: model
+    spec:
+      modelName: llama
+      poolRef:
+        name: other
+`,
+			wantErr: "must reference its pool",
+		},
+		{
+			name: "Model referencing a pool in another namespace",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: model
+    spec:
+      modelName: llama
+      poolRef:
+        namespace: other
+        name: pool
+`,
+			wantErr: "must reference its pool",
+		},
+		{
+			name: "Duplicate model names",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: model1
+    spec:
+      modelName: llama
+  - metadata:
+      name: model2
+    spec:
+      modelName: llama
+`,
+			wantErr: "duplicate modelName",
+		},
+		{
+			name: "Endpoint without an IP address",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  endpoints:
+  - name: gpu-0
+    address: localhost
+`,
+			wantErr: "must be an IP address",
+		},
+		{
+			name: "Duplicate endpoints",
+			data: `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  endpoints:
+  - name: gpu-0
+    address: 10.0.0.1
+  - name: gpu-0
+    address: 10.0.0.2
+`,
+			wantErr: "duplicate endpoint",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse([]byte(test.data))
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("Parse() error = %v, want error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected Parse() error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected config (-want +got): %s", diff)
+			}
+		})
+	}
+}
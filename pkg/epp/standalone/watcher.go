@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// DefaultRefreshInterval is the default interval of checking the config file for changes.
+const DefaultRefreshInterval = 2 * time.Second
+
+// Watcher populates the datastores of the pools from the config file at Path, and updates them
+// whenever the file changes. It takes the place of the reconcilers when running without
+// Kubernetes, and uses the same datastore methods. Pools of the config that are not served are
+// ignored, served pools missing from the config are cleared.
+//
+// The file is polled rather than watched for events, so that atomic replacements, e.g. of mounted
+// ConfigMaps, are picked up as well.
+type Watcher struct {
+	Path string
+	// Pools are the datastores of the InferencePools served by the EPP.
+	Pools           datastore.Pools
+	RefreshInterval time.Duration
+
+	// data is the content of the file last applied.
+	data []byte
+	// models are the InferenceModels last applied to each pool.
+	models map[types.NamespacedName][]v1alpha1.InferenceModel
+}
+
+// Start loads the config file and keeps the datastores up to date until the context is done. It
+// fails if the file cannot be loaded initially. Later invalid changes are logged and ignored, the
+// last valid config remains in effect.
+func (w *Watcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("path", w.Path)
+	if err := w.Sync(ctx); err != nil {
+		logger.Error(err, "Failed to load standalone config")
+		return err
+	}
+
+	interval := w.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.Sync(ctx); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "Ignoring invalid standalone config change")
+			}
+		}
+	}
+}
+
+// Sync reads the config file and applies it to the datastores if it changed since the last
+// successful sync.
+func (w *Watcher) Sync(ctx context.Context) error {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read standalone config: %w", err)
+	}
+	if w.data != nil && bytes.Equal(data, w.data) {
+		return nil
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).V(logutil.DEFAULT).Info("Applying standalone config", "path", w.Path)
+	w.apply(ctx, cfg)
+	w.data = data
+	return nil
+}
+
+func (w *Watcher) apply(ctx context.Context, cfg *Config) {
+	logger := log.FromContext(ctx)
+	if w.models == nil {
+		w.models = map[types.NamespacedName][]v1alpha1.InferenceModel{}
+	}
+
+	configured := map[types.NamespacedName]*PoolConfig{}
+	for i := range cfg.Pools {
+		pool := &cfg.Pools[i]
+		if _, ok := w.Pools[pool.NamespacedName()]; !ok {
+			logger.V(logutil.DEFAULT).Info("Ignoring InferencePool of the standalone config that is not served", "pool", pool.NamespacedName())
+			continue
+		}
+		configured[pool.NamespacedName()] = pool
+	}
+
+	for name, ds := range w.Pools {
+		pool, ok := configured[name]
+		if !ok {
+			if ds.PoolHasSynced() {
+				logger.V(logutil.DEFAULT).Info("InferencePool not found in the standalone config. Clearing the datastore", "pool", name)
+				ds.Clear()
+			}
+			delete(w.models, name)
+			continue
+		}
+		w.applyPool(ctx, ds, pool)
+	}
+}
+
+func (w *Watcher) applyPool(ctx context.Context, ds datastore.Datastore, cfg *PoolConfig) {
+	logger := log.FromContext(ctx).WithValues("pool", cfg.NamespacedName())
+	pool := cfg.InferencePool.DeepCopy()
+	ds.PoolSet(pool)
+
+	// Models that were removed or changed their model name are deleted before the current models are
+	// set, so that a model name moved to another InferenceModel is not deleted afterwards.
+	current := map[types.NamespacedName]string{}
+	for _, model := range cfg.InferenceModels {
+		current[types.NamespacedName{Namespace: model.Namespace, Name: model.Name}] = model.Spec.ModelName
+	}
+	for _, model := range w.models[cfg.NamespacedName()] {
+		namespacedName := types.NamespacedName{Namespace: model.Namespace, Name: model.Name}
+		if modelName, ok := current[namespacedName]; !ok || modelName != model.Spec.ModelName {
+			if ds.ModelDeleteByNamespacedName(namespacedName) {
+				logger.V(logutil.DEFAULT).Info("Removed InferenceModel", "modelName", model.Spec.ModelName)
+			}
+		}
+	}
+	for i := range cfg.InferenceModels {
+		model := cfg.InferenceModels[i].DeepCopy()
+		logger.V(logutil.VERBOSE).Info("Adding/Updating InferenceModel", "modelName", model.Spec.ModelName)
+		ds.ModelSet(model)
+	}
+	w.models[cfg.NamespacedName()] = cfg.InferenceModels
+
+	endpoints := map[types.NamespacedName]bool{}
+	for _, ep := range cfg.Endpoints {
+		namespacedName := types.NamespacedName{Namespace: pool.Namespace, Name: ep.Name}
+		endpoints[namespacedName] = true
+		if ds.PodUpdateOrAddEndpointIfNotExist(namespacedName, ep.Address, ep.Draining) {
+			logger.V(logutil.DEFAULT).Info("Pod added", "name", namespacedName, "address", ep.Address)
+		}
+	}
+	ds.PodRange(func(k, v any) bool {
+		namespacedName := k.(types.NamespacedName)
+		if !endpoints[namespacedName] {
+			logger.V(logutil.DEFAULT).Info("Pod removed", "name", namespacedName,
+				"inFlightRequests", ds.PodInFlightRequests(namespacedName))
+			ds.PodDelete(namespacedName)
+		}
+		return true
+	})
+}
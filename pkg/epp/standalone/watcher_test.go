@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+func TestWatcherSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "epp.yaml")
+	pool := types.NamespacedName{Namespace: "default", Name: "pool"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+	ds := datastore.NewDatastore()
+	otherDs := datastore.NewDatastore()
+	w := &Watcher{Path: path, Pools: datastore.Pools{pool: ds, other: otherDs}}
+
+	sync := func(step, data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("%s: failed to write config: %v", step, err)
+		}
+		if err := w.Sync(context.Background()); err != nil {
+			t.Fatalf("%s: unexpected Sync error: %v", step, err)
+		}
+	}
+	pod := func(name, address string, draining bool) datastore.Pod {
+		return datastore.Pod{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+			Address:        address,
+			ScrapePath:     "/metrics",
+			ScrapePort:     8000,
+			Draining:       draining,
+		}
+	}
+	check := func(step string, wantModels map[string]string, wantPods []datastore.Pod) {
+		t.Helper()
+		if !ds.PoolHasSynced() {
+			t.Fatalf("%s: pool not synced", step)
+		}
+		for modelName, name := range wantModels {
+			model, ok := ds.ModelGet(modelName)
+			if !ok {
+				t.Errorf("%s: model %q not found", step, modelName)
+				continue
+			}
+			if model.Name != name {
+				t.Errorf("%s: model %q is from InferenceModel %q, want %q", step, modelName, model.Name, name)
+			}
+		}
+		var gotPods []datastore.Pod
+		for _, pm := range ds.PodGetAll() {
+			gotPods = append(gotPods, pm.Pod)
+		}
+		sortPods := cmpopts.SortSlices(func(a, b datastore.Pod) bool { return a.NamespacedName.String() < b.NamespacedName.String() })
+		if diff := cmp.Diff(wantPods, gotPods, sortPods); diff != "" {
+			t.Errorf("%s: unexpected pods (-want +got): %s", step, diff)
+		}
+		if otherDs.PoolHasSynced() {
+			t.Errorf("%s: pool missing from the config is synced", step)
+		}
+	}
+
+	sync("initial", `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: llama
+    spec:
+      modelName: llama
+  - metadata:
+      name: mistral
+    spec:
+      modelName: mistral
+  endpoints:
+  - name: gpu-0
+    address: 10.0.0.1
+  - name: gpu-1
+    address: fd00::1
+- inferencePool:
+    metadata:
+      name: unserved
+    spec:
+      targetPortNumber: 8000
+`)
+	check("initial", map[string]string{"llama": "llama", "mistral": "mistral"},
+		[]datastore.Pod{pod("gpu-0", "10.0.0.1", false), pod("gpu-1", "fd00::1", false)})
+
+	sync("updated", `
+pools:
+- inferencePool:
+    metadata:
+      name: pool
+    spec:
+      targetPortNumber: 8000
+  inferenceModels:
+  - metadata:
+      name: llama
+    spec:
+      modelName: llama-2
+  endpoints:
+  - name: gpu-0
+    address: 10.0.0.1
+    draining: true
+  - name: gpu-2
+    address: 10.0.0.2
+`)
+	check("updated", map[string]string{"llama-2": "llama"},
+		[]datastore.Pod{pod("gpu-0", "10.0.0.1", true), pod("gpu-2", "10.0.0.2", false)})
+	for _, modelName := range []string{"llama", "mistral"} {
+		if _, ok := ds.ModelGet(modelName); ok {
+			t.Errorf("updated: model %q not removed", modelName)
+		}
+	}
+
+	// Invalid changes are rejected and the last valid config remains in effect.
+	if err := os.WriteFile(path, []byte("pools: [{inferencePool: {metadata: {name: pool}}}]"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := w.Sync(context.Background()); err == nil {
+		t.Errorf("Expected an error syncing an invalid config")
+	}
+	check("invalid", map[string]string{"llama-2": "llama"},
+		[]datastore.Pod{pod("gpu-0", "10.0.0.1", true), pod("gpu-2", "10.0.0.2", false)})
+
+	sync("pool removed", "pools: []")
+	if ds.PoolHasSynced() {
+		t.Errorf("pool removed: datastore of the pool not cleared")
+	}
+}
@@ -64,7 +64,7 @@ func StartExtProc(
 		datastore.PodUpdateMetricsIfExist(pm.NamespacedName, &pm.Metrics)
 	}
 	pp := backend.NewProvider(pmc, datastore)
-	if err := pp.Init(ctx, refreshMetricsInterval, refreshPrometheusMetricsInterval); err != nil {
+	if err := pp.Init(ctx, refreshMetricsInterval, refreshPrometheusMetricsInterval, scheduling.DefaultMetricsStalenessThreshold); err != nil {
 		logutil.Fatal(logger, err, "Failed to initialize")
 	}
 	return startExtProc(logger, port, datastore)
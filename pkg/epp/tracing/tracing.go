@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing sets up the OpenTelemetry tracing of the Endpoint Picker.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer of the Endpoint Picker spans.
+	TracerName = "sigs.k8s.io/gateway-api-inference-extension/epp"
+	// ServiceName is the service name reported with the Endpoint Picker spans.
+	ServiceName = "inference-extension-epp"
+)
+
+// Attributes of the Endpoint Picker spans.
+const (
+	// PoolKey is the namespaced name of the InferencePool the request is routed to, formatted as
+	// "namespace/name".
+	PoolKey = attribute.Key("inference.pool")
+	// ModelKey is the model requested.
+	ModelKey = attribute.Key("inference.model")
+	// TargetModelKey is the target model the requested model is resolved to.
+	TargetModelKey = attribute.Key("inference.target_model")
+	// PodKey is the namespaced name of the pod the request is scheduled to.
+	PodKey = attribute.Key("inference.pod")
+	// EndpointKey is the address of the endpoint the request is scheduled to.
+	EndpointKey = attribute.Key("inference.endpoint")
+)
+
+// Propagator is the propagator of the trace context between Envoy, the Endpoint Picker and the
+// model servers. It propagates the W3C trace context and baggage headers.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{}, propagation.Baggage{})
+
+// Options configures the export of the Endpoint Picker spans.
+type Options struct {
+	// Endpoint is the address of the OTLP gRPC collector, tracing is disabled if empty.
+	Endpoint string
+	// Insecure disables the transport security of the connection to the collector.
+	Insecure bool
+	// SamplingRatio is the ratio of the traces sampled if the parent span is not sampled already,
+	// between 0 and 1.
+	SamplingRatio float64
+}
+
+// Tracer returns the tracer of the Endpoint Picker spans, provided by the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Setup installs the global tracer provider exporting the spans to the OTLP collector of the
+// options. It returns the function flushing and stopping the export, to call on shutdown. The
+// spans are not exported if no endpoint is configured, the trace context of the requests is still
+// propagated to the model servers.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if opts.SamplingRatio < 0 || opts.SamplingRatio > 1 {
+		return nil, fmt.Errorf("tracing sampling ratio %v is not between 0 and 1", opts.SamplingRatio)
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+	}
+	tp := NewTracerProvider(sdktrace.NewBatchSpanProcessor(exporter), opts.SamplingRatio)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// NewTracerProvider returns a tracer provider sending the Endpoint Picker spans to the given span
+// processor. Spans are sampled if their parent is, or else with the given ratio.
+func NewTracerProvider(processor sdktrace.SpanProcessor, samplingRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+}
+
+// HeaderCarrier adapts the headers of an ext_proc message to a propagation.TextMapCarrier, for
+// the extraction of the trace context. Header keys are lower-case in ext_proc messages.
+type HeaderCarrier []*configPb.HeaderValue
+
+var _ propagation.TextMapCarrier = (*HeaderCarrier)(nil)
+
+// Get returns the value of the header with the given key.
+func (c HeaderCarrier) Get(key string) string {
+	for _, h := range c {
+		if h.Key == key {
+			if len(h.RawValue) > 0 {
+				return string(h.RawValue)
+			}
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// Set appends a header with the given key and value.
+func (c *HeaderCarrier) Set(key, value string) {
+	*c = append(*c, &configPb.HeaderValue{Key: key, RawValue: []byte(value)})
+}
+
+// Keys returns the keys of the headers.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, h := range c {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
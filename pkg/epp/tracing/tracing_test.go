@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHeaderCarrier(t *testing.T) {
+	c := HeaderCarrier{
+		{Key: "traceparent", RawValue: []byte("raw")},
+		{Key: "tracestate", Value: "value"},
+	}
+	c.Set("baggage", "set")
+
+	for key, want := range map[string]string{
+		"traceparent": "raw",
+		"tracestate":  "value",
+		"baggage":     "set",
+		"unknown":     "",
+	} {
+		if got := c.Get(key); got != want {
+			t.Errorf("Unexpected value of header %q, got %q, want %q", key, got, want)
+		}
+	}
+	if diff := cmp.Diff([]string{"traceparent", "tracestate", "baggage"}, c.Keys()); diff != "" {
+		t.Errorf("Unexpected keys (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff(&configPb.HeaderValue{Key: "baggage", RawValue: []byte("set")}, c[2], cmp.Comparer(func(a, b *configPb.HeaderValue) bool {
+		return a.Key == b.Key && a.Value == b.Value && string(a.RawValue) == string(b.RawValue)
+	})); diff != "" {
+		t.Errorf("Unexpected header set (-want +got): %s", diff)
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name: "disabled",
+			opts: Options{SamplingRatio: 2},
+		},
+		{
+			name:    "invalid sampling ratio",
+			opts:    Options{Endpoint: "localhost:4317", SamplingRatio: 2},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), test.opts)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unexpected error, got %v, want error %v", err, test.wantErr)
+			}
+			if err == nil {
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("Unexpected shutdown error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the validating admission webhook of the InferenceModels and
+// InferencePools. It applies the same rules as the datastore, so invalid objects are rejected when
+// they are written instead of surfacing at request time.
+//
+// The same webhook server serves the conversion webhook of the CRDs between the v1alpha1 and
+// v1alpha2 API versions.
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+)
+
+// SetupWithManager registers the validating webhooks of the InferenceModels and InferencePools
+// with the webhook server of the manager. The validators read from the API server, since the
+// manager cache only contains the namespaces of the served pools. The conversion webhook is
+// registered at /convert if the scheme of the manager contains both API versions.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.InferenceModel{}).
+		WithValidator(&InferenceModelValidator{Client: mgr.GetAPIReader()}).
+		Complete(); err != nil {
+		return fmt.Errorf("failed setting up InferenceModel webhook: %w", err)
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.InferencePool{}).
+		WithValidator(&InferencePoolValidator{Client: mgr.GetAPIReader()}).
+		Complete(); err != nil {
+		return fmt.Errorf("failed setting up InferencePool webhook: %w", err)
+	}
+	return nil
+}
+
+// InferenceModelValidator rejects invalid InferenceModels, see datastore.ValidateInferenceModel.
+// It warns about InferenceModels referencing a missing pool or a pool in another namespace without
+// an InferencePoolGrant, and about model names already used in the pool, since only one of the
+// InferenceModels using a model name is accepted.
+type InferenceModelValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &InferenceModelValidator{}
+
+func (v *InferenceModelValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+func (v *InferenceModelValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+func (v *InferenceModelValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *InferenceModelValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	model, ok := obj.(*v1alpha1.InferenceModel)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceModel, got %T", obj)
+	}
+	if errs := datastore.ValidateInferenceModel(model); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("InferenceModel").GroupKind(), model.Name, errs)
+	}
+
+	var warnings admission.Warnings
+	poolName := datastore.PoolRefName(model)
+	pool := &v1alpha1.InferencePool{}
+	if err := v.Client.Get(ctx, poolName, pool); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get InferencePool %q: %w", poolName, err)
+		}
+		warnings = append(warnings, fmt.Sprintf("spec.poolRef: InferencePool %q not found in namespace %q, the model is not served until it is created",
+			poolName.Name, poolName.Namespace))
+	}
+	grants := &v1alpha1.InferencePoolGrantList{}
+	if err := v.Client.List(ctx, grants, client.InNamespace(poolName.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list InferencePoolGrants: %w", err)
+	}
+	if !datastore.ReferenceGranted(model, grants.Items) {
+		warnings = append(warnings, fmt.Sprintf("spec.poolRef: no InferencePoolGrant in namespace %q allows InferenceModels in namespace %q to reference InferencePool %q, the model is not served until one does",
+			poolName.Namespace, model.Namespace, poolName.Name))
+		return warnings, nil
+	}
+
+	models := &v1alpha1.InferenceModelList{}
+	if err := v.Client.List(ctx, models); err != nil {
+		return nil, fmt.Errorf("failed to list InferenceModels: %w", err)
+	}
+	incoming := model
+	if incoming.CreationTimestamp.IsZero() {
+		// The model is being created, and is newer than all existing ones.
+		incoming = model.DeepCopy()
+		incoming.CreationTimestamp = metav1.Now()
+	}
+	for i := range models.Items {
+		other := &models.Items[i]
+		if (other.Namespace == model.Namespace && other.Name == model.Name) || datastore.PoolRefName(other) != poolName ||
+			other.Spec.ModelName != model.Spec.ModelName || !other.DeletionTimestamp.IsZero() ||
+			!datastore.ReferenceGranted(other, grants.Items) {
+			continue
+		}
+		if datastore.InferenceModelPrecedes(other, incoming) {
+			warnings = append(warnings, fmt.Sprintf("spec.modelName: %q is already used by the older InferenceModel %q in InferencePool %q, this InferenceModel will not be accepted",
+				model.Spec.ModelName, client.ObjectKeyFromObject(other), poolName))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("spec.modelName: %q is also used by the newer InferenceModel %q in InferencePool %q, which will not be accepted",
+				model.Spec.ModelName, client.ObjectKeyFromObject(other), poolName))
+		}
+	}
+	return warnings, nil
+}
+
+// InferencePoolValidator rejects invalid InferencePools, see datastore.ValidateInferencePool. It
+// warns about InferenceModels left dangling when a pool they reference is deleted.
+type InferencePoolValidator struct {
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &InferencePoolValidator{}
+
+func (v *InferencePoolValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+func (v *InferencePoolValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+func (v *InferencePoolValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*v1alpha1.InferencePool)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferencePool, got %T", obj)
+	}
+	models := &v1alpha1.InferenceModelList{}
+	if err := v.Client.List(ctx, models); err != nil {
+		// Deletion is never rejected, the warning is best effort.
+		return nil, nil
+	}
+	var referencing []string
+	for i := range models.Items {
+		if datastore.PoolRefName(&models.Items[i]) == client.ObjectKeyFromObject(pool) {
+			referencing = append(referencing, client.ObjectKeyFromObject(&models.Items[i]).String())
+		}
+	}
+	if len(referencing) == 0 {
+		return nil, nil
+	}
+	return admission.Warnings{fmt.Sprintf("InferenceModels %v still reference InferencePool %q and will not be served", referencing, pool.Name)}, nil
+}
+
+func (v *InferencePoolValidator) validate(obj runtime.Object) error {
+	pool, ok := obj.(*v1alpha1.InferencePool)
+	if !ok {
+		return fmt.Errorf("expected an InferencePool, got %T", obj)
+	}
+	if errs := datastore.ValidateInferencePool(pool); len(errs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("InferencePool").GroupKind(), pool.Name, errs)
+	}
+	return nil
+}
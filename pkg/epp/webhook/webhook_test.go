@@ -0,0 +1,276 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+)
+
+var (
+	pool = &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: v1alpha1.InferencePoolSpec{
+			TargetPortNumber: 8000,
+			Selector:         map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"},
+		},
+	}
+	existingModel = &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default", CreationTimestamp: metav1.Unix(1000, 0)},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "llama",
+			PoolRef:   v1alpha1.PoolObjectReference{Name: "pool"},
+		},
+	}
+	grant = &v1alpha1.InferencePoolGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "default"},
+		Spec: v1alpha1.InferencePoolGrantSpec{
+			From: []v1alpha1.InferencePoolGrantFrom{{Namespace: "team"}},
+			To:   []v1alpha1.InferencePoolGrantTo{{}},
+		},
+	}
+)
+
+func newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestInferenceModelValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		objs         []client.Object
+		model        *v1alpha1.InferenceModel
+		wantInvalid  bool
+		wantWarnings []string
+	}{
+		{
+			name: "Valid model",
+			objs: []client.Object{pool},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Name: "pool"},
+				},
+			},
+		},
+		{
+			name: "Invalid target model weights",
+			objs: []client.Object{pool},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName:    "llama",
+					PoolRef:      v1alpha1.PoolObjectReference{Name: "pool"},
+					TargetModels: []v1alpha1.TargetModel{{Name: "v1", Weight: new(int32)}},
+				},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "Missing pool",
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Name: "pool"},
+				},
+			},
+			wantWarnings: []string{"spec.poolRef"},
+		},
+		{
+			name: "Model name used by an older model",
+			objs: []client.Object{pool, existingModel},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Name: "pool"},
+				},
+			},
+			wantWarnings: []string{"older InferenceModel \"default/existing\""},
+		},
+		{
+			name: "Model name used by a newer model",
+			objs: []client.Object{pool, existingModel},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default", CreationTimestamp: metav1.Unix(10, 0)},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Name: "pool"},
+				},
+			},
+			wantWarnings: []string{"newer InferenceModel \"default/existing\""},
+		},
+		{
+			name: "Cross-namespace reference without grant",
+			objs: []client.Object{pool, existingModel},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "team"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Namespace: "default", Name: "pool"},
+				},
+			},
+			wantWarnings: []string{"no InferencePoolGrant"},
+		},
+		{
+			name: "Granted cross-namespace reference",
+			objs: []client.Object{pool, existingModel, grant},
+			model: &v1alpha1.InferenceModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "team"},
+				Spec: v1alpha1.InferenceModelSpec{
+					ModelName: "llama",
+					PoolRef:   v1alpha1.PoolObjectReference{Namespace: "default", Name: "pool"},
+				},
+			},
+			wantWarnings: []string{"older InferenceModel \"default/existing\""},
+		},
+		{
+			name:  "Updating the model using the name",
+			objs:  []client.Object{pool, existingModel},
+			model: existingModel,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &InferenceModelValidator{Client: newClient(test.objs...)}
+			warnings, err := v.ValidateCreate(context.Background(), test.model)
+			if test.wantInvalid {
+				if !apierrors.IsInvalid(err) {
+					t.Fatalf("Expected an Invalid error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(warnings) != len(test.wantWarnings) {
+				t.Fatalf("Expected warnings matching %v, got %v", test.wantWarnings, warnings)
+			}
+			for i, want := range test.wantWarnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("Expected warning %q to contain %q", warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestInferencePoolValidator(t *testing.T) {
+	crossNamespaceModel := &v1alpha1.InferenceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "cross-namespace", Namespace: "team"},
+		Spec: v1alpha1.InferenceModelSpec{
+			ModelName: "mistral",
+			PoolRef:   v1alpha1.PoolObjectReference{Namespace: "default", Name: "pool"},
+		},
+	}
+	v := &InferencePoolValidator{Client: newClient(pool, existingModel, crossNamespaceModel)}
+	ctx := context.Background()
+
+	if _, err := v.ValidateCreate(ctx, pool); err != nil {
+		t.Errorf("Unexpected error for a valid pool: %v", err)
+	}
+	invalid := pool.DeepCopy()
+	invalid.Spec.Selector = nil
+	if _, err := v.ValidateUpdate(ctx, pool, invalid); !apierrors.IsInvalid(err) {
+		t.Errorf("Expected an Invalid error for a pool without selector, got %v", err)
+	}
+
+	warnings, err := v.ValidateDelete(ctx, pool)
+	if err != nil {
+		t.Fatalf("Unexpected error on delete: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "default/existing") || !strings.Contains(warnings[0], "team/cross-namespace") {
+		t.Errorf("Expected a warning about the referencing InferenceModel, got %v", warnings)
+	}
+	unreferenced := pool.DeepCopy()
+	unreferenced.Name = "other"
+	if warnings, _ := v.ValidateDelete(ctx, unreferenced); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for an unreferenced pool, got %v", warnings)
+	}
+}
+
+func TestConversionWebhook(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
+	for _, obj := range []runtime.Object{&v1alpha1.InferenceModel{}, &v1alpha1.InferencePool{}} {
+		if ok, err := conversion.IsConvertible(scheme, obj); err != nil || !ok {
+			t.Errorf("Expected %T to be convertible, got %v, %v", obj, ok, err)
+		}
+	}
+
+	src := pool.DeepCopy()
+	src.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "InferencePool"}
+	raw, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	review, err := json.Marshal(&apix.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apix.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apix.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: v1alpha2.GroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(review))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	conversion.NewWebhookHandler(scheme).ServeHTTP(rec, req)
+
+	resp := &apix.ConversionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Failed to decode the response %q: %v", rec.Body.String(), err)
+	}
+	if resp.Response == nil || resp.Response.Result.Status != metav1.StatusSuccess || len(resp.Response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected one converted object, got %+v", resp.Response)
+	}
+	got := &v1alpha2.InferencePool{}
+	if err := json.Unmarshal(resp.Response.ConvertedObjects[0].Raw, got); err != nil {
+		t.Fatal(err)
+	}
+	want := metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}}
+	if diff := cmp.Diff(want, got.Spec.Selector); diff != "" {
+		t.Errorf("Unexpected selector (-want +got): %s", diff)
+	}
+	if got.APIVersion != v1alpha2.GroupVersion.String() {
+		t.Errorf("Expected apiVersion %s, got %s", v1alpha2.GroupVersion, got.APIVersion)
+	}
+}
@@ -390,16 +390,16 @@ func setUpHermeticServer(podMetrics []*datastore.PodMetrics) (client extProcPb.E
 
 	serverCtx, stopServer := context.WithCancel(context.Background())
 	go func() {
-		serverRunner.Datastore.PodDeleteAll()
+		serverRunner.Pools[0].Datastore.PodDeleteAll()
 		for _, pm := range podMetrics {
 			pod := utiltesting.MakePod(pm.NamespacedName.Name, pm.NamespacedName.Namespace).
 				ReadyCondition().
 				IP(pm.Address).
 				Obj()
-			serverRunner.Datastore.PodUpdateOrAddIfNotExist(&pod)
-			serverRunner.Datastore.PodUpdateMetricsIfExist(pm.NamespacedName, &pm.Metrics)
+			serverRunner.Pools[0].Datastore.PodUpdateOrAddIfNotExist(&pod)
+			serverRunner.Pools[0].Datastore.PodUpdateMetricsIfExist(pm.NamespacedName, &pm.Metrics)
 		}
-		serverRunner.Provider = backend.NewProvider(pmc, serverRunner.Datastore)
+		serverRunner.Pools[0].Provider = backend.NewProvider(pmc, serverRunner.Pools[0].Datastore)
 		if err := serverRunner.AsRunnable(logger.WithName("ext-proc")).Start(serverCtx); err != nil {
 			logutil.Fatal(logger, err, "Failed to start ext-proc server")
 		}
@@ -457,9 +457,13 @@ func BeforeSuit(t *testing.T) func() {
 
 	serverRunner = runserver.NewDefaultExtProcServerRunner()
 	// Adjust from defaults
-	serverRunner.PoolName = "vllm-llama2-7b-pool"
-	serverRunner.Datastore = datastore.NewDatastore()
+	serverRunner.Pools = []*runserver.Pool{{
+		NamespacedName: types.NamespacedName{Namespace: runserver.DefaultPoolNamespace, Name: "vllm-llama2-7b-pool"},
+		Datastore:      datastore.NewDatastore(),
+	}}
 	serverRunner.SecureServing = false
+	// Each test stops its server, there are no clients to drain.
+	serverRunner.ShutdownDrainPeriod = 0
 
 	if err := serverRunner.SetupWithManager(mgr); err != nil {
 		logutil.Fatal(logger, err, "Failed to setup server runner")
@@ -507,8 +511,8 @@ func BeforeSuit(t *testing.T) func() {
 	}
 
 	assert.EventuallyWithT(t, func(t *assert.CollectT) {
-		_, modelExist := serverRunner.Datastore.ModelGet("my-model")
-		synced := serverRunner.Datastore.PoolHasSynced() && modelExist
+		_, modelExist := serverRunner.Pools[0].Datastore.ModelGet("my-model")
+		synced := serverRunner.Pools[0].Datastore.PoolHasSynced() && modelExist
 		assert.True(t, synced, "Timeout waiting for the pool and models to sync")
 	}, 10*time.Second, 10*time.Millisecond)
 
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// TestInferenceModelStatus verifies that the EPP resolves duplicate model names by creation
+// timestamp and writes the Accepted and Ready conditions of the InferenceModels.
+func TestInferenceModelStatus(t *testing.T) {
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		logutil.Fatal(logger, err, "Failed to start test environment", "config", cfg)
+	}
+	defer func() { _ = env.Stop() }()
+
+	statusScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(statusScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(statusScheme))
+	c, err := k8sclient.New(cfg, k8sclient.Options{Scheme: statusScheme})
+	if err != nil {
+		t.Fatalf("Failed to create k8s client: %v", err)
+	}
+
+	ctrl.SetLogger(logger)
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  statusScheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create controller manager: %v", err)
+	}
+	poolName := types.NamespacedName{Namespace: "default", Name: "status-pool"}
+	ds := datastore.NewDatastore()
+	runner := runserver.NewDefaultExtProcServerRunner()
+	runner.Pools = []*runserver.Pool{{NamespacedName: poolName, Datastore: ds}}
+	if err := runner.SetupWithManager(mgr); err != nil {
+		t.Fatalf("Failed to setup server runner: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			logutil.Fatal(logger, err, "Failed to start manager")
+		}
+	}()
+
+	pool := &v1alpha1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName.Name, Namespace: poolName.Namespace},
+		Spec: v1alpha1.InferencePoolSpec{
+			TargetPortNumber: 8000,
+			Selector:         map[v1alpha1.LabelKey]v1alpha1.LabelValue{"app": "vllm"},
+		},
+	}
+	if err := c.Create(ctx, pool); err != nil {
+		t.Fatalf("Failed to create InferencePool: %v", err)
+	}
+	newModel := func(name string) *v1alpha1.InferenceModel {
+		return &v1alpha1.InferenceModel{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: poolName.Namespace},
+			Spec: v1alpha1.InferenceModelSpec{
+				ModelName: "status-model",
+				PoolRef:   v1alpha1.PoolObjectReference{Name: poolName.Name},
+			},
+		}
+	}
+	// The older model wins, even though its name sorts last. Creation timestamps have a resolution
+	// of a second.
+	older := newModel("older")
+	if err := c.Create(ctx, older); err != nil {
+		t.Fatalf("Failed to create InferenceModel: %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	newer := newModel("a-newer")
+	if err := c.Create(ctx, newer); err != nil {
+		t.Fatalf("Failed to create InferenceModel: %v", err)
+	}
+
+	checkStatus := func(name string, want metav1.ConditionStatus, wantReason v1alpha1.InferenceModelConditionReason) {
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			model := &v1alpha1.InferenceModel{}
+			if !assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: poolName.Namespace, Name: name}, model)) {
+				return
+			}
+			accepted := meta.FindStatusCondition(model.Status.Conditions, string(v1alpha1.ModelConditionAccepted))
+			if assert.NotNil(t, accepted, "Accepted condition of %s", name) {
+				assert.Equal(t, want, accepted.Status, "Accepted condition of %s", name)
+				assert.Equal(t, string(wantReason), accepted.Reason, "Accepted condition of %s", name)
+			}
+			ready := meta.FindStatusCondition(model.Status.Conditions, string(v1alpha1.ModelConditionReady))
+			if assert.NotNil(t, ready, "Ready condition of %s", name) {
+				assert.Equal(t, want, ready.Status, "Ready condition of %s", name)
+			}
+		}, 10*time.Second, 50*time.Millisecond)
+	}
+	checkStored := func(want string) {
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			model, ok := ds.ModelGet("status-model")
+			if assert.True(t, ok, "model not stored") {
+				assert.Equal(t, want, model.Name)
+			}
+		}, 10*time.Second, 50*time.Millisecond)
+	}
+
+	checkStatus("older", metav1.ConditionTrue, v1alpha1.ModelReasonAccepted)
+	checkStatus("a-newer", metav1.ConditionFalse, v1alpha1.ModelReasonNameInUse)
+	checkStored("older")
+
+	// Deleting the accepted model lets the duplicate take over.
+	if err := c.Delete(ctx, older); err != nil {
+		t.Fatalf("Failed to delete InferenceModel: %v", err)
+	}
+	checkStatus("a-newer", metav1.ConditionTrue, v1alpha1.ModelReasonAccepted)
+	checkStored("a-newer")
+}